@@ -0,0 +1,302 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+type Aggregate struct {
+	title   string
+	page    int
+	limit   int
+	items   []AggregateItem
+	updated time.Time
+	mutex   sync.Mutex
+}
+
+type AggregateFeed struct {
+	XMLName xml.Name         `xml:"rss"`
+	Version string           `xml:"version,attr"`
+	Atom    string           `xml:"xmlns:atom,attr"`
+	Channel AggregateChannel `xml:"channel"`
+}
+
+type AggregateLink struct {
+	Rel  string `xml:"rel,attr"`
+	Href string `xml:"href,attr"`
+}
+
+type AggregateChannel struct {
+	Title         string          `xml:"title"`
+	Link          string          `xml:"link"`
+	Description   string          `xml:"description"`
+	LastBuildDate string          `xml:"lastBuildDate,omitempty"`
+	Links         []AggregateLink `xml:"atom:link"`
+	Items         []AggregateItem `xml:"item"`
+}
+
+type AggregateItem struct {
+	Title       string          `xml:"title,omitempty"`
+	Link        string          `xml:"link,omitempty"`
+	Description string          `xml:"description,omitempty"`
+	PubDate     string          `xml:"pubDate,omitempty"`
+	GUID        AggregateGUID   `xml:"guid"`
+	Source      AggregateSource `xml:"source"`
+	Language    string          `xml:"-"`
+	Terms       []string        `xml:"-"`
+	Record      Record          `xml:"-"`
+}
+
+type AggregateFilter struct {
+	Feeds    []string
+	Language string
+	Tags     []string
+}
+
+type AggregateGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type AggregateSource struct {
+	URL   string `xml:"url,attr"`
+	Title string `xml:",chardata"`
+}
+
+func newAggregate(title string, page int, limit int) *Aggregate {
+	return &Aggregate{title: title, page: page, limit: limit}
+}
+
+func (a *Aggregate) Name() string {
+	return "served feed"
+}
+
+func (a *Aggregate) Key() string {
+	return "serve"
+}
+
+func (a *Aggregate) Deliver(entry *Entry) error {
+	item := aggregateItem(entry)
+	item.Record = entry.Record()
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.items = append([]AggregateItem{item}, a.items...)
+	if len(a.items) > a.limit {
+		a.items = a.items[:a.limit]
+	}
+	a.updated = clock.Now()
+	return nil
+}
+
+func aggregateItem(entry *Entry) AggregateItem {
+	item := AggregateItem{
+		Title:       strip(entry.Item.Title),
+		Link:        entry.Item.Link,
+		Description: entry.text(),
+		GUID:        AggregateGUID{Value: entry.guid()},
+		Source:      AggregateSource{URL: entry.Feed, Title: entry.source()},
+		Language:    language(entry.Item, &entry.Channel),
+		Terms:       keywords(entry),
+	}
+	if !entry.Published.IsZero() {
+		item.PubDate = entry.Published.Format(time.RFC1123Z)
+	}
+	return item
+}
+
+func (a *Aggregate) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter, err := aggregateFilter(query)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	number, err := pageNumber(query)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	base := scheme + "://" + r.Host + r.URL.Path
+	a.mutex.Lock()
+	var items []AggregateItem
+	for _, item := range a.items {
+		if filter.matches(&item) {
+			items = append(items, item)
+		}
+	}
+	pages := max(1, (len(items)+a.page-1)/a.page)
+	if number > pages {
+		a.mutex.Unlock()
+		http.NotFound(w, r)
+		return
+	}
+	page := func(n int) string {
+		query.Del("page")
+		if n > 1 {
+			query.Set("page", strconv.Itoa(n))
+		}
+		if len(query) == 0 {
+			return base
+		}
+		return base + "?" + query.Encode()
+	}
+	links := []AggregateLink{{"self", page(number)}, {"first", page(1)}, {"last", page(pages)}}
+	if number > 1 {
+		links = append(links, AggregateLink{"previous", page(number - 1)})
+	}
+	if number < pages {
+		links = append(links, AggregateLink{"next", page(number + 1)})
+	}
+	feed := AggregateFeed{
+		Version: "2.0",
+		Atom:    "http://www.w3.org/2005/Atom",
+		Channel: AggregateChannel{
+			Title:       a.title,
+			Link:        base,
+			Description: "Items filtered by rssp",
+			Links:       links,
+			Items:       items[(number-1)*a.page : min(number*a.page, len(items))],
+		},
+	}
+	updated := a.updated
+	if !updated.IsZero() {
+		feed.Channel.LastBuildDate = updated.Format(time.RFC1123Z)
+	}
+	body, err := xml.MarshalIndent(feed, "", "  ")
+	a.mutex.Unlock()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	body = append([]byte(xml.Header), body...)
+	sum := sha256.Sum256(body)
+	etag := hex.EncodeToString(sum[:8])
+	w.Header().Set("Content-Type", "application/rss+xml; charset=utf-8")
+	w.Header().Set("Vary", "Accept-Encoding")
+	if strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+		var compressed bytes.Buffer
+		writer := gzip.NewWriter(&compressed)
+		writer.Write(body)
+		writer.Close()
+		body = compressed.Bytes()
+		etag += "-gzip"
+		w.Header().Set("Content-Encoding", "gzip")
+	}
+	w.Header().Set("ETag", `"`+etag+`"`)
+	http.ServeContent(w, r, "", updated, bytes.NewReader(body))
+}
+
+func (a *Aggregate) Records(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter, err := aggregateFilter(query)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	number, err := pageNumber(query)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	records := []Record{}
+	a.mutex.Lock()
+	for _, item := range a.items {
+		if filter.matches(&item) {
+			records = append(records, item.Record)
+		}
+	}
+	a.mutex.Unlock()
+	records = records[min((number-1)*a.page, len(records)):min(number*a.page, len(records))]
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(records)
+}
+
+func pageNumber(query url.Values) (int, error) {
+	value := query.Get("page")
+	if value == "" {
+		return 1, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("invalid page")
+	}
+	return n, nil
+}
+
+func aggregateFilter(query url.Values) (*AggregateFilter, error) {
+	filter := &AggregateFilter{Language: query.Get("lang")}
+	for name, values := range query {
+		switch name {
+		case "page", "lang":
+		case "feed":
+			filter.Feeds = values
+		case "tags":
+			for _, value := range values {
+				for _, tag := range strings.Split(value, ",") {
+					if tag = strings.TrimSpace(tag); tag != "" {
+						filter.Tags = append(filter.Tags, strings.ToLower(tag))
+					}
+				}
+			}
+		case "min_score":
+			return nil, fmt.Errorf("min_score is not supported, items have no score")
+		default:
+			return nil, fmt.Errorf("unknown parameter %s", name)
+		}
+	}
+	return filter, nil
+}
+
+func (f *AggregateFilter) matches(item *AggregateItem) bool {
+	if len(f.Feeds) > 0 && !slices.Contains(f.Feeds, item.Source.URL) {
+		return false
+	}
+	if f.Language != "" && !strings.HasPrefix(strings.ToLower(item.Language), strings.ToLower(f.Language)) {
+		return false
+	}
+	if len(f.Tags) == 0 {
+		return true
+	}
+	words := tokens(item.Title + " " + item.Description + " " + strings.Join(item.Terms, " "))
+	for _, tag := range f.Tags {
+		if phrase := tokens(tag); len(phrase) > 0 && containsPhrase(words, phrase) {
+			return true
+		}
+	}
+	return false
+}
+
+func tokens(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func containsPhrase(words []string, phrase []string) bool {
+	for i := 0; i+len(phrase) <= len(words); i++ {
+		if slices.Equal(words[i:i+len(phrase)], phrase) {
+			return true
+		}
+	}
+	return false
+}
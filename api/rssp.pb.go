@@ -0,0 +1,332 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: rssp.proto
+
+package api
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type FilterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Feeds         []string               `protobuf:"bytes,1,rep,name=feeds,proto3" json:"feeds,omitempty"`
+	Keywords      []string               `protobuf:"bytes,2,rep,name=keywords,proto3" json:"keywords,omitempty"`
+	Language      string                 `protobuf:"bytes,3,opt,name=language,proto3" json:"language,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FilterRequest) Reset() {
+	*x = FilterRequest{}
+	mi := &file_rssp_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FilterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilterRequest) ProtoMessage() {}
+
+func (x *FilterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rssp_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilterRequest.ProtoReflect.Descriptor instead.
+func (*FilterRequest) Descriptor() ([]byte, []int) {
+	return file_rssp_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FilterRequest) GetFeeds() []string {
+	if x != nil {
+		return x.Feeds
+	}
+	return nil
+}
+
+func (x *FilterRequest) GetKeywords() []string {
+	if x != nil {
+		return x.Keywords
+	}
+	return nil
+}
+
+func (x *FilterRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+type Item struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schema        string                 `protobuf:"bytes,1,opt,name=schema,proto3" json:"schema,omitempty"`
+	Feed          string                 `protobuf:"bytes,2,opt,name=feed,proto3" json:"feed,omitempty"`
+	Channel       string                 `protobuf:"bytes,3,opt,name=channel,proto3" json:"channel,omitempty"`
+	Language      string                 `protobuf:"bytes,4,opt,name=language,proto3" json:"language,omitempty"`
+	Title         string                 `protobuf:"bytes,5,opt,name=title,proto3" json:"title,omitempty"`
+	Link          string                 `protobuf:"bytes,6,opt,name=link,proto3" json:"link,omitempty"`
+	Image         string                 `protobuf:"bytes,7,opt,name=image,proto3" json:"image,omitempty"`
+	Description   string                 `protobuf:"bytes,8,opt,name=description,proto3" json:"description,omitempty"`
+	Content       string                 `protobuf:"bytes,9,opt,name=content,proto3" json:"content,omitempty"`
+	Summary       string                 `protobuf:"bytes,10,opt,name=summary,proto3" json:"summary,omitempty"`
+	Published     string                 `protobuf:"bytes,11,opt,name=published,proto3" json:"published,omitempty"`
+	Guid          string                 `protobuf:"bytes,12,opt,name=guid,proto3" json:"guid,omitempty"`
+	People        []string               `protobuf:"bytes,13,rep,name=people,proto3" json:"people,omitempty"`
+	Companies     []string               `protobuf:"bytes,14,rep,name=companies,proto3" json:"companies,omitempty"`
+	Products      []string               `protobuf:"bytes,15,rep,name=products,proto3" json:"products,omitempty"`
+	Archived      string                 `protobuf:"bytes,16,opt,name=archived,proto3" json:"archived,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Item) Reset() {
+	*x = Item{}
+	mi := &file_rssp_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Item) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Item) ProtoMessage() {}
+
+func (x *Item) ProtoReflect() protoreflect.Message {
+	mi := &file_rssp_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Item.ProtoReflect.Descriptor instead.
+func (*Item) Descriptor() ([]byte, []int) {
+	return file_rssp_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Item) GetSchema() string {
+	if x != nil {
+		return x.Schema
+	}
+	return ""
+}
+
+func (x *Item) GetFeed() string {
+	if x != nil {
+		return x.Feed
+	}
+	return ""
+}
+
+func (x *Item) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *Item) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *Item) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Item) GetLink() string {
+	if x != nil {
+		return x.Link
+	}
+	return ""
+}
+
+func (x *Item) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *Item) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Item) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *Item) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+func (x *Item) GetPublished() string {
+	if x != nil {
+		return x.Published
+	}
+	return ""
+}
+
+func (x *Item) GetGuid() string {
+	if x != nil {
+		return x.Guid
+	}
+	return ""
+}
+
+func (x *Item) GetPeople() []string {
+	if x != nil {
+		return x.People
+	}
+	return nil
+}
+
+func (x *Item) GetCompanies() []string {
+	if x != nil {
+		return x.Companies
+	}
+	return nil
+}
+
+func (x *Item) GetProducts() []string {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *Item) GetArchived() string {
+	if x != nil {
+		return x.Archived
+	}
+	return ""
+}
+
+var File_rssp_proto protoreflect.FileDescriptor
+
+const file_rssp_proto_rawDesc = "" +
+	"\n" +
+	"\n" +
+	"rssp.proto\x12\arssp.v1\"]\n" +
+	"\rFilterRequest\x12\x14\n" +
+	"\x05feeds\x18\x01 \x03(\tR\x05feeds\x12\x1a\n" +
+	"\bkeywords\x18\x02 \x03(\tR\bkeywords\x12\x1a\n" +
+	"\blanguage\x18\x03 \x01(\tR\blanguage\"\x9e\x03\n" +
+	"\x04Item\x12\x16\n" +
+	"\x06schema\x18\x01 \x01(\tR\x06schema\x12\x12\n" +
+	"\x04feed\x18\x02 \x01(\tR\x04feed\x12\x18\n" +
+	"\achannel\x18\x03 \x01(\tR\achannel\x12\x1a\n" +
+	"\blanguage\x18\x04 \x01(\tR\blanguage\x12\x14\n" +
+	"\x05title\x18\x05 \x01(\tR\x05title\x12\x12\n" +
+	"\x04link\x18\x06 \x01(\tR\x04link\x12\x14\n" +
+	"\x05image\x18\a \x01(\tR\x05image\x12 \n" +
+	"\vdescription\x18\b \x01(\tR\vdescription\x12\x18\n" +
+	"\acontent\x18\t \x01(\tR\acontent\x12\x18\n" +
+	"\asummary\x18\n" +
+	" \x01(\tR\asummary\x12\x1c\n" +
+	"\tpublished\x18\v \x01(\tR\tpublished\x12\x12\n" +
+	"\x04guid\x18\f \x01(\tR\x04guid\x12\x16\n" +
+	"\x06people\x18\r \x03(\tR\x06people\x12\x1c\n" +
+	"\tcompanies\x18\x0e \x03(\tR\tcompanies\x12\x1a\n" +
+	"\bproducts\x18\x0f \x03(\tR\bproducts\x12\x1a\n" +
+	"\barchived\x18\x10 \x01(\tR\barchived2=\n" +
+	"\x05Items\x124\n" +
+	"\tSubscribe\x12\x16.rssp.v1.FilterRequest\x1a\r.rssp.v1.Item0\x01B\n" +
+	"Z\brssp/apib\x06proto3"
+
+var (
+	file_rssp_proto_rawDescOnce sync.Once
+	file_rssp_proto_rawDescData []byte
+)
+
+func file_rssp_proto_rawDescGZIP() []byte {
+	file_rssp_proto_rawDescOnce.Do(func() {
+		file_rssp_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rssp_proto_rawDesc), len(file_rssp_proto_rawDesc)))
+	})
+	return file_rssp_proto_rawDescData
+}
+
+var file_rssp_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rssp_proto_goTypes = []any{
+	(*FilterRequest)(nil), // 0: rssp.v1.FilterRequest
+	(*Item)(nil),          // 1: rssp.v1.Item
+}
+var file_rssp_proto_depIdxs = []int32{
+	0, // 0: rssp.v1.Items.Subscribe:input_type -> rssp.v1.FilterRequest
+	1, // 1: rssp.v1.Items.Subscribe:output_type -> rssp.v1.Item
+	1, // [1:2] is the sub-list for method output_type
+	0, // [0:1] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_rssp_proto_init() }
+func file_rssp_proto_init() {
+	if File_rssp_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rssp_proto_rawDesc), len(file_rssp_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_rssp_proto_goTypes,
+		DependencyIndexes: file_rssp_proto_depIdxs,
+		MessageInfos:      file_rssp_proto_msgTypes,
+	}.Build()
+	File_rssp_proto = out.File
+	file_rssp_proto_goTypes = nil
+	file_rssp_proto_depIdxs = nil
+}
@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	archiveURL      = "https://web.archive.org/save/"
+	archiveInterval = 10 * time.Second
+	archiving       bool
+	archiveMutex    sync.Mutex
+	lastArchive     time.Time
+)
+
+func archive(ctx context.Context, link string, httpClient HTTPClient) string {
+	archiveMutex.Lock()
+	slot := lastArchive.Add(archiveInterval)
+	if now := clock.Now(); slot.Before(now) {
+		slot = now
+	}
+	lastArchive = slot
+	archiveMutex.Unlock()
+	if wait := slot.Sub(clock.Now()); wait > 0 && !sleep(ctx, wait) {
+		if logger != nil {
+			logger.Printf("Gave up archiving %s while waiting for its turn", link)
+		}
+		return ""
+	}
+	if logger != nil {
+		logger.Printf("Submitting %s to the Wayback Machine", link)
+	}
+	resp, err := httpClient.Get(archiveURL + link)
+	if err != nil {
+		if logger != nil {
+			logger.Printf("Failed to archive %s: %v", link, err)
+		}
+		return ""
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		if logger != nil {
+			logger.Printf("Wayback Machine error %d for %s", resp.StatusCode, link)
+		}
+		return ""
+	}
+	archived := ""
+	if location := resp.Header.Get("Content-Location"); location != "" {
+		base, err := url.Parse(archiveURL)
+		if err == nil {
+			if ref, err := base.Parse(location); err == nil {
+				archived = ref.String()
+			}
+		}
+	} else if resp.Request != nil && resp.Request.URL != nil && !strings.HasPrefix(resp.Request.URL.String(), archiveURL) {
+		archived = resp.Request.URL.String()
+	}
+	if logger != nil && archived != "" {
+		logger.Printf("Archived %s as %s", link, archived)
+	}
+	return archived
+}
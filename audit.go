@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+)
+
+type Call struct {
+	Time     time.Time `json:"time"`
+	Item     string    `json:"item,omitempty"`
+	Purpose  string    `json:"purpose"`
+	Model    string    `json:"model,omitempty"`
+	Prompt   string    `json:"prompt_hash,omitempty"`
+	Decision string    `json:"decision,omitempty"`
+	Tokens   int       `json:"tokens,omitempty"`
+	Latency  int64     `json:"latency_ms"`
+	Error    string    `json:"error,omitempty"`
+}
+
+var (
+	auditFile  *os.File
+	auditMutex sync.Mutex
+)
+
+func (c *Call) record() {
+	if c == nil || auditFile == nil {
+		return
+	}
+	line, err := json.Marshal(c)
+	if err != nil {
+		return
+	}
+	auditMutex.Lock()
+	defer auditMutex.Unlock()
+	if _, err := auditFile.Write(append(line, '\n')); err != nil && logger != nil {
+		logger.Printf("Failed to write to the audit log: %v", err)
+	}
+}
@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type Bandwidth struct {
+	mutex   sync.Mutex
+	limit   int64
+	day     string
+	today   int64
+	overall int64
+	feeds   map[string]int64
+}
+
+type meteredClient struct {
+	client HTTPClient
+	feed   string
+}
+
+type countingBody struct {
+	io.ReadCloser
+	feed string
+}
+
+var bandwidth = &Bandwidth{feeds: make(map[string]int64)}
+
+func (b *Bandwidth) add(feed string, n int64) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.rollover()
+	b.today += n
+	b.overall += n
+	b.feeds[feed] += n
+}
+
+func (b *Bandwidth) exceeded() bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.rollover()
+	return b.limit > 0 && b.today >= b.limit
+}
+
+func (b *Bandwidth) usage(feed string) (int64, int64, int64) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.rollover()
+	return b.feeds[feed], b.today, b.overall
+}
+
+func (b *Bandwidth) snapshot() (int64, int64, map[string]int64) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.rollover()
+	feeds := make(map[string]int64, len(b.feeds))
+	for feed, n := range b.feeds {
+		feeds[feed] = n
+	}
+	return b.today, b.overall, feeds
+}
+
+func (b *Bandwidth) rollover() {
+	day := clock.Now().Format("2006-01-02")
+	if b.day != day {
+		b.day = day
+		b.today = 0
+	}
+}
+
+func (m *meteredClient) Get(url string) (*http.Response, error) {
+	if bandwidth.exceeded() {
+		return nil, fmt.Errorf("daily bandwidth limit of %d bytes reached", bandwidth.limit)
+	}
+	resp, err := m.client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = &countingBody{ReadCloser: resp.Body, feed: m.feed}
+	return resp, nil
+}
+
+func (m *meteredClient) Do(req *http.Request) (*http.Response, error) {
+	if bandwidth.exceeded() {
+		return nil, fmt.Errorf("daily bandwidth limit of %d bytes reached", bandwidth.limit)
+	}
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = &countingBody{ReadCloser: resp.Body, feed: m.feed}
+	return resp, nil
+}
+
+func (c *countingBody) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	bandwidth.add(c.feed, int64(n))
+	return n, err
+}
+
+func parseSize(size string) (int64, error) {
+	size = strings.ToUpper(strings.TrimSpace(size))
+	units := []struct {
+		suffix     string
+		multiplier int64
+	}{
+		{"GB", 1024 * 1024 * 1024},
+		{"MB", 1024 * 1024},
+		{"KB", 1024},
+		{"B", 1},
+	}
+	multiplier := int64(1)
+	for _, unit := range units {
+		if strings.HasSuffix(size, unit.suffix) {
+			multiplier = unit.multiplier
+			size = strings.TrimSpace(strings.TrimSuffix(size, unit.suffix))
+			break
+		}
+	}
+	n, err := strconv.ParseInt(size, 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size: %q", size)
+	}
+	return n * multiplier, nil
+}
+
+func formatSize(n int64) string {
+	switch {
+	case n >= 1024*1024*1024:
+		return fmt.Sprintf("%.1fGB", float64(n)/(1024*1024*1024))
+	case n >= 1024*1024:
+		return fmt.Sprintf("%.1fMB", float64(n)/(1024*1024))
+	case n >= 1024:
+		return fmt.Sprintf("%.1fKB", float64(n)/1024)
+	default:
+		return fmt.Sprintf("%dB", n)
+	}
+}
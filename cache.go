@@ -0,0 +1,165 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+type CachedPage struct {
+	URL          string `json:"url"`
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+	Body         string `json:"body"`
+}
+
+var cacheDir string
+
+func cachePath(link string) string {
+	sum := sha256.Sum256([]byte(link))
+	return filepath.Join(cacheDir, hex.EncodeToString(sum[:])+".json")
+}
+
+func loadPage(link string) *CachedPage {
+	if cacheDir == "" {
+		return nil
+	}
+	data, err := os.ReadFile(cachePath(link))
+	if err != nil {
+		return nil
+	}
+	var page CachedPage
+	err = json.Unmarshal(data, &page)
+	if err != nil || page.URL != link {
+		return nil
+	}
+	return &page
+}
+
+func storePage(link string, header http.Header, body []byte) {
+	if cacheDir == "" {
+		return
+	}
+	page := CachedPage{
+		URL:          link,
+		ETag:         header.Get("ETag"),
+		LastModified: header.Get("Last-Modified"),
+		Body:         string(body),
+	}
+	if page.ETag == "" && page.LastModified == "" {
+		return
+	}
+	data, err := json.Marshal(page)
+	if err == nil {
+		err = os.MkdirAll(cacheDir, 0755)
+	}
+	if err == nil {
+		err = os.WriteFile(cachePath(link), data, 0644)
+	}
+	if err != nil && logger != nil {
+		logger.Printf("Failed to cache %s: %v", link, err)
+	}
+}
+
+type Summary struct {
+	Content  string `json:"content"`
+	Relevant bool   `json:"relevant"`
+}
+
+type SummaryCache struct {
+	limit   int
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+type cachedSummary struct {
+	key     string
+	summary Summary
+}
+
+const maxSummaries = 1000
+
+var (
+	summaries      = newSummaryCache(maxSummaries)
+	summariesMutex sync.Mutex
+)
+
+func newSummaryCache(limit int) *SummaryCache {
+	return &SummaryCache{limit: limit, entries: make(map[string]*list.Element), order: list.New()}
+}
+
+func (c *SummaryCache) get(key string) (Summary, bool) {
+	element, ok := c.entries[key]
+	if !ok {
+		return Summary{}, false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*cachedSummary).summary, true
+}
+
+func (c *SummaryCache) put(key string, summary Summary) {
+	if element, ok := c.entries[key]; ok {
+		element.Value.(*cachedSummary).summary = summary
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&cachedSummary{key: key, summary: summary})
+	for c.order.Len() > c.limit {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cachedSummary).key)
+	}
+}
+
+func summaryKey(prompt string) string {
+	sum := sha256.Sum256([]byte(prompt))
+	return hex.EncodeToString(sum[:])
+}
+
+func loadSummary(key string) (Summary, bool) {
+	summariesMutex.Lock()
+	defer summariesMutex.Unlock()
+	if summary, ok := summaries.get(key); ok {
+		return summary, true
+	}
+	if cacheDir == "" {
+		return Summary{}, false
+	}
+	data, err := os.ReadFile(filepath.Join(cacheDir, "summary-"+key+".json"))
+	if err != nil {
+		return Summary{}, false
+	}
+	var summary Summary
+	if json.Unmarshal(data, &summary) != nil {
+		return Summary{}, false
+	}
+	summaries.put(key, summary)
+	return summary, true
+}
+
+func storeSummary(key string, summary Summary) {
+	summariesMutex.Lock()
+	defer summariesMutex.Unlock()
+	summaries.put(key, summary)
+	if cacheDir == "" {
+		return
+	}
+	data, err := json.Marshal(summary)
+	if err == nil {
+		err = os.MkdirAll(cacheDir, 0755)
+	}
+	if err == nil {
+		err = os.WriteFile(filepath.Join(cacheDir, "summary-"+key+".json"), data, 0644)
+	}
+	if err != nil && logger != nil {
+		logger.Printf("Failed to cache summary %s: %v", key, err)
+	}
+}
@@ -0,0 +1,217 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Calendar struct {
+	path   string
+	events map[string]Event
+	dirty  bool
+	mutex  sync.Mutex
+}
+
+type Event struct {
+	Start string
+	Text  string
+}
+
+const monthNames = `(jan(?:uary)?|feb(?:ruary)?|mar(?:ch)?|apr(?:il)?|may|june?|july?|aug(?:ust)?|sep(?:t(?:ember)?)?|oct(?:ober)?|nov(?:ember)?|dec(?:ember)?)`
+
+var (
+	isoDateRe    = regexp.MustCompile(`\b(\d{4})-(\d{2})-(\d{2})\b`)
+	monthFirstRe = regexp.MustCompile(`(?i)\b` + monthNames + `\.?\s+(\d{1,2})(?:st|nd|rd|th)?(?:\s*[-–]\s*\d{1,2})?,?\s+(\d{4})\b`)
+	dayFirstRe   = regexp.MustCompile(`(?i)\b(\d{1,2})(?:st|nd|rd|th)?(?:\s*[-–]\s*\d{1,2})?\s+` + monthNames + `\.?,?\s+(\d{4})\b`)
+	eventUIDRe   = regexp.MustCompile(`(?m)^UID:(.+?)\r?$`)
+	eventStartRe = regexp.MustCompile(`(?m)^DTSTART;VALUE=DATE:(\d{8})\r?$`)
+)
+
+func newCalendar(path string) (*Calendar, error) {
+	c := &Calendar{path: path, events: make(map[string]Event)}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, block := range strings.Split(string(data), "BEGIN:VEVENT")[1:] {
+		end := strings.Index(block, "END:VEVENT")
+		if end < 0 {
+			return nil, fmt.Errorf("%s has an unterminated VEVENT", path)
+		}
+		block = strings.TrimLeft(block[:end], "\r\n")
+		uid := eventUIDRe.FindStringSubmatch(block)
+		start := eventStartRe.FindStringSubmatch(block)
+		if uid == nil || start == nil {
+			continue
+		}
+		c.events[uid[1]] = Event{Start: start[1], Text: block}
+	}
+	return c, nil
+}
+
+func (c *Calendar) Name() string {
+	return "calendar"
+}
+
+func (c *Calendar) Key() string {
+	return "ics"
+}
+
+func (c *Calendar) Deliver(entry *Entry) error {
+	title := strip(entry.Item.Title)
+	text := entry.text()
+	date, ok := eventDate(title+"\n"+text+"\n"+entry.Content, entry.Published)
+	if !ok {
+		return nil
+	}
+	uid := summaryKey(getItemID(entry.Item))[:16] + "@rssp"
+	start := date.Format("20060102")
+	var event strings.Builder
+	for _, line := range []string{
+		"UID:" + uid,
+		"DTSTAMP:" + clock.Now().UTC().Format("20060102T150405Z"),
+		"DTSTART;VALUE=DATE:" + start,
+		"DTEND;VALUE=DATE:" + date.AddDate(0, 0, 1).Format("20060102"),
+		"SUMMARY:" + icsEscape(title),
+		"DESCRIPTION:" + icsEscape(truncate(text, 1000)),
+		"URL:" + entry.Item.Link,
+	} {
+		if strings.HasSuffix(line, ":") {
+			continue
+		}
+		event.WriteString(icsFold(line))
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.events[uid] = Event{Start: start, Text: event.String()}
+	c.dirty = true
+	return nil
+}
+
+func (c *Calendar) Flush() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if !c.dirty {
+		return nil
+	}
+	uids := make([]string, 0, len(c.events))
+	for uid := range c.events {
+		uids = append(uids, uid)
+	}
+	sort.Slice(uids, func(i, j int) bool {
+		a, b := c.events[uids[i]], c.events[uids[j]]
+		if a.Start != b.Start {
+			return a.Start < b.Start
+		}
+		return uids[i] < uids[j]
+	})
+	var ics strings.Builder
+	ics.WriteString("BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//rssp//rssp//EN\r\nCALSCALE:GREGORIAN\r\n")
+	for _, uid := range uids {
+		ics.WriteString("BEGIN:VEVENT\r\n")
+		ics.WriteString(c.events[uid].Text)
+		ics.WriteString("END:VEVENT\r\n")
+	}
+	ics.WriteString("END:VCALENDAR\r\n")
+	temp, err := os.CreateTemp(filepath.Dir(c.path), ".rssp-calendar-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name())
+	if _, err := temp.WriteString(ics.String()); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(temp.Name(), c.path); err != nil {
+		return err
+	}
+	c.dirty = false
+	return nil
+}
+
+func eventDate(text string, published time.Time) (time.Time, bool) {
+	type found struct {
+		at   int
+		date time.Time
+	}
+	var dates []found
+	for _, match := range isoDateRe.FindAllStringSubmatchIndex(text, -1) {
+		if date, ok := calendarDate(text[match[2]:match[3]], text[match[4]:match[5]], text[match[6]:match[7]]); ok {
+			dates = append(dates, found{match[0], date})
+		}
+	}
+	for _, match := range monthFirstRe.FindAllStringSubmatchIndex(text, -1) {
+		if date, ok := calendarDate(text[match[6]:match[7]], text[match[2]:match[3]], text[match[4]:match[5]]); ok {
+			dates = append(dates, found{match[0], date})
+		}
+	}
+	for _, match := range dayFirstRe.FindAllStringSubmatchIndex(text, -1) {
+		if date, ok := calendarDate(text[match[6]:match[7]], text[match[4]:match[5]], text[match[2]:match[3]]); ok {
+			dates = append(dates, found{match[0], date})
+		}
+	}
+	sort.SliceStable(dates, func(i, j int) bool { return dates[i].at < dates[j].at })
+	day := published.Format("2006-01-02")
+	for _, d := range dates {
+		if published.IsZero() || d.date.Format("2006-01-02") != day {
+			return d.date, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func calendarDate(year string, month string, day string) (time.Time, bool) {
+	y, _ := strconv.Atoi(year)
+	d, _ := strconv.Atoi(day)
+	m, err := strconv.Atoi(month)
+	if err != nil {
+		m = 0
+		prefix := strings.ToLower(month[:3])
+		for i := time.January; i <= time.December; i++ {
+			if strings.ToLower(i.String()[:3]) == prefix {
+				m = int(i)
+			}
+		}
+	}
+	date := time.Date(y, time.Month(m), d, 0, 0, 0, 0, time.UTC)
+	if y < 1900 || date.Month() != time.Month(m) || date.Day() != d {
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+func icsEscape(text string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(text)
+}
+
+func icsFold(line string) string {
+	var folded strings.Builder
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > 75 {
+			folded.WriteString("\r\n ")
+			width = 1
+		}
+		folded.WriteRune(r)
+		width += size
+	}
+	folded.WriteString("\r\n")
+	return folded.String()
+}
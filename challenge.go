@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+var (
+	scrapingProxy    string
+	challengeMarkers = map[string][]string{
+		"Cloudflare": {"cf-browser-verification", "cf_chl_opt", "challenge-platform", "<title>Just a moment...</title>", "Attention Required! | Cloudflare"},
+		"Imperva":    {"_Incapsula_Resource", "Incapsula incident ID"},
+		"DataDome":   {"captcha-delivery.com", "datadome"},
+		"PerimeterX": {"px-captcha", "_pxAppId"},
+	}
+)
+
+type ChallengeError struct {
+	Vendor string
+	Status string
+}
+
+func (e *ChallengeError) Error() string {
+	return fmt.Sprintf("blocked by %s anti-bot challenge (%s)", e.Vendor, e.Status)
+}
+
+func challengeVendor(header http.Header, body string) string {
+	if header.Get("Cf-Mitigated") == "challenge" {
+		return "Cloudflare"
+	}
+	for vendor, markers := range challengeMarkers {
+		for _, marker := range markers {
+			if strings.Contains(body, marker) {
+				return vendor
+			}
+		}
+	}
+	return ""
+}
+
+func unblock(resp *http.Response, link string, httpClient HTTPClient) (*http.Response, error) {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusServiceUnavailable && resp.StatusCode != http.StatusTooManyRequests {
+		return resp, nil
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	resp.Body.Close()
+	vendor := challengeVendor(resp.Header, string(body))
+	if vendor == "" {
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		return resp, nil
+	}
+	blocked := &ChallengeError{Vendor: vendor, Status: resp.Status}
+	if scrapingProxy == "" {
+		return nil, blocked
+	}
+	if logger != nil {
+		logger.Printf("%s was %v, retrying through the scraping proxy", link, blocked)
+	}
+	return httpClient.Get(scrapingProxy + url.QueryEscape(link))
+}
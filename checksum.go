@@ -0,0 +1,37 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"crypto/sha256"
+	"sync"
+)
+
+type checksum struct {
+	sum [sha256.Size]byte
+	rss RSS
+}
+
+var (
+	checksums     = make(map[string]checksum)
+	checksumMutex sync.Mutex
+)
+
+func unchanged(url string, body []byte) (*RSS, bool) {
+	sum := sha256.Sum256(body)
+	checksumMutex.Lock()
+	defer checksumMutex.Unlock()
+	previous, ok := checksums[url]
+	if !ok || previous.sum != sum {
+		return nil, false
+	}
+	rss := previous.rss
+	return &rss, true
+}
+
+func remember(url string, body []byte, rss *RSS) {
+	checksumMutex.Lock()
+	defer checksumMutex.Unlock()
+	checksums[url] = checksum{sum: sha256.Sum256(body), rss: *rss}
+}
@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const commentInterval = 15 * time.Minute
+
+var (
+	commentWindow    time.Duration
+	commentThreshold = 50
+)
+
+func commentFeed(item *Item) string {
+	if item.CommentRSS != "" {
+		return item.CommentRSS
+	}
+	return item.Comments
+}
+
+func watchComments(entry *Entry) {
+	link := commentFeed(entry.Item)
+	if !fetchable(link) {
+		return
+	}
+	title := strip(entry.Item.Title)
+	started := clock.Now()
+	for clock.Now().Sub(started) < commentWindow {
+		feed, err := fetchComments(shutdown, link)
+		if err != nil {
+			if logger != nil {
+				logger.Printf("Stopped watching comments of '%s': %s", title, describe(err))
+			}
+			return
+		}
+		if count := len(feed.Channel.Items); count >= commentThreshold {
+			elapsed := clock.Now().Sub(started).Round(time.Minute)
+			if logger != nil {
+				logger.Printf("'%s' is blowing up: %d comments in %s", title, count, elapsed)
+			}
+			if outputFormat == "text" {
+				outputMutex.Lock()
+				writeOutput(fmt.Sprintf("%s is blowing up: %d comments in %s %s\n\n", title, count, elapsed, entry.Item.Link))
+				outputMutex.Unlock()
+			}
+			return
+		}
+		if !sleep(shutdown, commentInterval) {
+			return
+		}
+	}
+}
+
+func fetchComments(ctx context.Context, link string) (*RSS, error) {
+	resp, err := (&boundClient{client: client, ctx: ctx}).Get(link)
+	if err != nil {
+		return nil, fail(ErrFetch, fmt.Errorf("HTTP request failed: %w", err))
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fail(ErrFetch, fmt.Errorf("HTTP error: %s", resp.Status))
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxPageSize))
+	if err != nil {
+		return nil, fail(ErrFetch, fmt.Errorf("failed to read response body: %w", err))
+	}
+	return parseFeed(body)
+}
@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"errors"
+	"sync"
+)
+
+var errInFlight = errors.New("posting in the background")
+
+type Concurrent struct {
+	sink   Sink
+	slots  chan struct{}
+	group  sync.WaitGroup
+	mutex  sync.Mutex
+	failed []error
+}
+
+func newConcurrent(sink Sink, limit int) *Concurrent {
+	return &Concurrent{sink: sink, slots: make(chan struct{}, limit)}
+}
+
+func (c *Concurrent) Name() string {
+	return c.sink.Name()
+}
+
+func (c *Concurrent) Key() string {
+	return c.sink.Key()
+}
+
+func (c *Concurrent) Deliver(entry *Entry) error {
+	c.slots <- struct{}{}
+	c.group.Add(1)
+	go func() {
+		defer c.group.Done()
+		defer func() { <-c.slots }()
+		err := c.sink.Deliver(entry)
+		if err != nil {
+			c.mutex.Lock()
+			c.failed = append(c.failed, err)
+			c.mutex.Unlock()
+		}
+		if logger == nil {
+			return
+		}
+		if err != nil {
+			logger.Printf("Failed to deliver '%s' to %s: %v", entry.Item.Title, c.sink.Name(), err)
+		} else {
+			logger.Printf("Delivered '%s' to %s", entry.Item.Title, c.sink.Name())
+		}
+	}()
+	return errInFlight
+}
+
+func (c *Concurrent) Flush() error {
+	c.group.Wait()
+	c.mutex.Lock()
+	err := errors.Join(c.failed...)
+	c.failed = nil
+	c.mutex.Unlock()
+	if flusher, ok := c.sink.(Flusher); ok {
+		err = errors.Join(err, flusher.Flush())
+	}
+	return err
+}
@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var localMonths = [][]string{
+	{"january", "february", "march", "april", "may", "june", "july", "august", "september", "october", "november", "december"},
+	{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+	{"januar", "februar", "märz", "april", "mai", "juni", "juli", "august", "september", "oktober", "november", "dezember"},
+	{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+	{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+	{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+	{"januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus", "september", "oktober", "november", "december"},
+	{"январь", "февраль", "март", "апрель", "май", "июнь", "июль", "август", "сентябрь", "октябрь", "ноябрь", "декабрь"},
+	{"января", "февраля", "марта", "апреля", "мая", "июня", "июля", "августа", "сентября", "октября", "ноября", "декабря"},
+}
+
+var (
+	dayDotRe       = regexp.MustCompile(`(\d)\.(\s|$)`)
+	lenientLayouts = []string{
+		"2 Jan 2006 15:04:05 -0700",
+		"2 Jan 2006 15:04:05 -07:00",
+		"2 Jan 2006 15:04:05 -07",
+		"2 Jan 2006 15:04:05",
+		"2 Jan 2006 15:04 -0700",
+		"2 Jan 2006 15:04",
+		"2 Jan 2006",
+		"Jan 2 2006 15:04:05 -0700",
+		"Jan 2 2006 15:04:05",
+		"Jan 2 2006 15:04",
+		"Jan 2 2006",
+	}
+)
+
+func lenientTime(text string) (time.Time, bool) {
+	text = dayDotRe.ReplaceAllString(strings.ReplaceAll(text, ",", " "), "$1 ")
+	var tokens []string
+	first := -1
+	for _, token := range strings.Fields(text) {
+		word := strings.TrimSuffix(token, ".")
+		if strings.IndexFunc(word, func(r rune) bool { return !unicode.IsLetter(r) && r != '-' }) >= 0 {
+			tokens = append(tokens, token)
+			continue
+		}
+		if month, ok := monthOf(word); ok {
+			if first >= 0 {
+				tokens = append(tokens[:first], tokens[first+1:]...)
+			}
+			first = len(tokens)
+			tokens = append(tokens, month.String()[:3])
+		}
+	}
+	text = strings.Join(tokens, " ")
+	for _, layout := range lenientLayouts {
+		if t, err := time.Parse(layout, text); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func monthOf(word string) (time.Month, bool) {
+	word = strings.ToLower(word)
+	if len([]rune(word)) < 3 {
+		return 0, false
+	}
+	found := 0
+	for _, names := range localMonths {
+		for i, name := range names {
+			if !strings.HasPrefix(name, word) {
+				continue
+			}
+			if found != 0 && found != i+1 {
+				return 0, false
+			}
+			found = i + 1
+		}
+	}
+	return time.Month(found), found != 0
+}
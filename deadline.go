@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+type boundClient struct {
+	client HTTPClient
+	ctx    context.Context
+}
+
+var (
+	shutdown, stop = context.WithCancel(context.Background())
+	cycleTimeout   = 10 * time.Minute
+	itemTimeout    = 2 * time.Minute
+	drainTimeout   = 8 * time.Second
+)
+
+func (b *boundClient) Get(url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(b.ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return b.client.Do(req)
+}
+
+func (b *boundClient) Do(req *http.Request) (*http.Response, error) {
+	return b.client.Do(req.WithContext(b.ctx))
+}
+
+func deadline(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+type Discord struct {
+	webhook string
+	embeds  []DiscordEmbed
+	mutex   sync.Mutex
+}
+
+type DiscordMessage struct {
+	Embeds []DiscordEmbed `json:"embeds"`
+}
+
+type DiscordEmbed struct {
+	Title       string         `json:"title,omitempty"`
+	URL         string         `json:"url,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Timestamp   string         `json:"timestamp,omitempty"`
+	Footer      *DiscordFooter `json:"footer,omitempty"`
+}
+
+type DiscordFooter struct {
+	Text string `json:"text"`
+}
+
+type DiscordError struct {
+	status int
+}
+
+const (
+	discordBatch = 10
+	discordLimit = 6000
+)
+
+func (d *Discord) Name() string {
+	return "Discord"
+}
+
+func (d *Discord) Key() string {
+	return "discord"
+}
+
+func (d *Discord) Deliver(entry *Entry) error {
+	embed := DiscordEmbed{
+		Title:  truncate(strip(entry.Item.Title), 256),
+		URL:    entry.Item.Link,
+		Footer: &DiscordFooter{Text: truncate(entry.source(), 1024)},
+	}
+	embed.Description = truncate(entry.text(), min(4096, discordLimit-embed.size()))
+	if !entry.Published.IsZero() {
+		embed.Timestamp = entry.Published.UTC().Format(time.RFC3339)
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.embeds = append(d.embeds, embed)
+	if len(d.embeds) >= discordBatch {
+		return d.send()
+	}
+	return nil
+}
+
+func (d *Discord) Flush() error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.send()
+}
+
+func (d *Discord) send() error {
+	var failure error
+	for len(d.embeds) > 0 {
+		n, size := 1, d.embeds[0].size()
+		for n < len(d.embeds) && n < discordBatch && size+d.embeds[n].size() <= discordLimit {
+			size += d.embeds[n].size()
+			n++
+		}
+		err := d.post(DiscordMessage{Embeds: d.embeds[:n]})
+		var rejected *DiscordError
+		if errors.As(err, &rejected) && rejected.status/100 == 4 {
+			if logger != nil {
+				logger.Printf("Discord rejected %d embeds, dropping them: %v", n, err)
+			}
+			d.embeds = d.embeds[n:]
+			failure = err
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		d.embeds = d.embeds[n:]
+	}
+	return failure
+}
+
+func (e DiscordEmbed) size() int {
+	size := utf8.RuneCountInString(e.Title) + utf8.RuneCountInString(e.Description)
+	if e.Footer != nil {
+		size += utf8.RuneCountInString(e.Footer.Text)
+	}
+	return size
+}
+
+func (e *DiscordError) Error() string {
+	return fmt.Sprintf("Discord webhook error %d", e.status)
+}
+
+func (d *Discord) post(message DiscordMessage) error {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	for attempt := 0; ; attempt++ {
+		resp, err := sinkClient.Post(d.webhook, "application/json", bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("failed to post to Discord: %w", err)
+		}
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusTooManyRequests && attempt == 0 {
+			wait, err := strconv.ParseFloat(resp.Header.Get("Retry-After"), 64)
+			if err != nil {
+				wait = 1
+			}
+			clock.Sleep(time.Duration(wait * float64(time.Second)))
+			continue
+		}
+		if resp.StatusCode/100 != 2 {
+			return &DiscordError{status: resp.StatusCode}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var feedLinkRe = regexp.MustCompile(`(?i)<link[^>]+type\s*=\s*["']application/(?:rss|atom|feed)\+(?:xml|json)["'][^>]*>`)
+
+func webPage(body []byte) bool {
+	return strings.HasPrefix(http.DetectContentType(skipJunk(body)), "text/html")
+}
+
+func discover(link string, page []byte) []string {
+	base, err := url.Parse(link)
+	if err != nil {
+		return nil
+	}
+	var feeds []string
+	for _, tag := range feedLinkRe.FindAllString(string(page), -1) {
+		match := hrefRe.FindStringSubmatch(tag)
+		if len(match) < 2 {
+			continue
+		}
+		feed, err := base.Parse(match[1])
+		if err != nil {
+			continue
+		}
+		feeds = append(feeds, feed.String())
+	}
+	return feeds
+}
+
+func misconfigured(link string, page []byte) error {
+	feeds := discover(link, page)
+	if len(feeds) == 0 {
+		return fail(ErrParse, fmt.Errorf("%s is a web page, not a feed, and it does not advertise any feed in its <link rel=\"alternate\"> tags, pass the URL of the RSS or Atom feed itself", link))
+	}
+	return fail(ErrParse, fmt.Errorf("%s is a web page, not a feed, it advertises %s, pass that URL instead", link, strings.Join(feeds, " and ")))
+}
@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrFetch   = errors.New("fetch failed")
+	ErrParse   = errors.New("parse failed")
+	ErrCharset = errors.New("unsupported charset")
+	ErrLLM     = errors.New("LLM request failed")
+	ErrConfig  = errors.New("invalid configuration")
+)
+
+const (
+	exitOK      = 0
+	exitFailure = 1
+	exitConfig  = 2
+)
+
+type Failure struct {
+	kind error
+	err  error
+}
+
+func fail(kind error, err error) error {
+	return &Failure{kind: kind, err: err}
+}
+
+func (f *Failure) Error() string {
+	return f.err.Error()
+}
+
+func (f *Failure) Unwrap() []error {
+	return []error{f.kind, f.err}
+}
+
+func code(err error) string {
+	switch {
+	case errors.Is(err, ErrCharset):
+		return "E_CHARSET"
+	case errors.Is(err, ErrParse):
+		return "E_PARSE"
+	case errors.Is(err, ErrFetch):
+		return "E_FETCH"
+	case errors.Is(err, ErrLLM):
+		return "E_LLM"
+	default:
+		return "E_INTERNAL"
+	}
+}
+
+func describe(err error) string {
+	return fmt.Sprintf("[%s] %v", code(err), err)
+}
@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+type Fixture struct {
+	Name     string `json:"-"`
+	Topic    string `json:"topic"`
+	Content  string `json:"content"`
+	Language string `json:"language"`
+	Relevant bool   `json:"relevant"`
+}
+
+type Score struct {
+	Correct        int
+	FalsePositives int
+	FalseNegatives int
+}
+
+func evaluate(args []string, out io.Writer) int {
+	flags := flag.NewFlagSet("eval", flag.ContinueOnError)
+	dir := flags.String("fixtures", "", "Directory with *.json fixtures: {\"topic\", \"content\", \"language\", \"relevant\"}")
+	promptA := flags.String("prompt-a", "", "Prompt template A, in the format of prompt.txt")
+	promptB := flags.String("prompt-b", "", "Prompt template B, in the format of prompt.txt")
+	topic := flags.String("focus", "", "Topic to use for fixtures that don't have one")
+	if err := flags.Parse(args); err != nil {
+		return exitConfig
+	}
+	if *dir == "" || *promptA == "" || *promptB == "" {
+		fmt.Fprintf(os.Stderr, "Error: --fixtures, --prompt-a and --prompt-b are required\n")
+		return exitConfig
+	}
+	token := os.Getenv("OPENAI_API_KEY")
+	if token == "" {
+		fmt.Fprintf(os.Stderr, "Error: OPENAI_API_KEY is not set\n")
+		return exitConfig
+	}
+	fixtures, err := loadFixtures(*dir, *topic)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+	var templates [2]string
+	for i, path := range []string{*promptA, *promptB} {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return exitFailure
+		}
+		templates[i] = string(data)
+	}
+	var scores [2]Score
+	agreed := 0
+	table := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "FIXTURE\tEXPECTED\tA\tB")
+	for _, fixture := range fixtures {
+		var verdicts [2]bool
+		for i, text := range templates {
+			prompt, err := render(text, fixture)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: prompt %c: %v\n", 'A'+i, err)
+				return exitFailure
+			}
+			response, err := complete(context.Background(), token, prompt, nil)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s with prompt %c: %v\n", fixture.Name, 'A'+i, err)
+				return exitFailure
+			}
+			verdicts[i] = !strings.HasPrefix(response, "NOT_RELEVANT")
+			scores[i].count(fixture.Relevant, verdicts[i])
+		}
+		if verdicts[0] == verdicts[1] {
+			agreed++
+		}
+		if verdicts[0] != fixture.Relevant || verdicts[1] != fixture.Relevant {
+			fmt.Fprintf(table, "%s\t%s\t%s\t%s\n", fixture.Name, label(fixture.Relevant), label(verdicts[0]), label(verdicts[1]))
+		}
+	}
+	table.Flush()
+	fmt.Fprintln(out)
+	for i, score := range scores {
+		fmt.Fprintf(out, "Prompt %c: %d/%d correct, %d false positives, %d false negatives\n",
+			'A'+i, score.Correct, len(fixtures), score.FalsePositives, score.FalseNegatives)
+	}
+	fmt.Fprintf(out, "A and B agree on %d/%d fixtures\n", agreed, len(fixtures))
+	return exitOK
+}
+
+func loadFixtures(dir string, topic string) ([]Fixture, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no *.json fixtures found in %s", dir)
+	}
+	sort.Strings(paths)
+	fixtures := make([]Fixture, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		fixture := Fixture{Topic: topic}
+		if err := json.Unmarshal(data, &fixture); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		if fixture.Topic == "" {
+			return nil, fmt.Errorf("%s has no topic and --focus is not set", path)
+		}
+		fixture.Name = strings.TrimSuffix(filepath.Base(path), ".json")
+		fixtures = append(fixtures, fixture)
+	}
+	return fixtures, nil
+}
+
+func (s *Score) count(expected bool, actual bool) {
+	switch {
+	case expected == actual:
+		s.Correct++
+	case actual:
+		s.FalsePositives++
+	default:
+		s.FalseNegatives++
+	}
+}
+
+func label(relevant bool) string {
+	if relevant {
+		return "relevant"
+	}
+	return "not relevant"
+}
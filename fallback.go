@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+)
+
+type WaybackResponse struct {
+	ArchivedSnapshots struct {
+		Closest struct {
+			Available bool   `json:"available"`
+			URL       string `json:"url"`
+		} `json:"closest"`
+	} `json:"archived_snapshots"`
+}
+
+var (
+	waybackURL       = "https://archive.org/wayback/available"
+	fallbackFetching bool
+	ampLinkRe        = regexp.MustCompile(`(?i)<link[^>]+rel\s*=\s*["']amphtml["'][^>]*>`)
+	hrefRe           = regexp.MustCompile(`(?i)href\s*=\s*["']([^"']+)["']`)
+)
+
+func extractFallback(link, page string, httpClient HTTPClient) string {
+	best := ""
+	for _, alternate := range []string{ampVersion(link, page), archivedVersion(link, httpClient)} {
+		if alternate == "" {
+			continue
+		}
+		if !fetchable(alternate) {
+			if logger != nil {
+				logger.Printf("Refusing to fetch alternate version %s of %s: only http and https links are followed", alternate, link)
+			}
+			continue
+		}
+		if logger != nil {
+			logger.Printf("Trying alternate version %s of %s", alternate, link)
+		}
+		text := extractBasicContent(alternate, httpClient)
+		if len(text) > len(best) {
+			best = text
+		}
+		if len(best) >= minTextLength {
+			break
+		}
+	}
+	return best
+}
+
+func ampVersion(link, page string) string {
+	if len(page) > maxPageSize {
+		page = page[:maxPageSize]
+	}
+	tag := ampLinkRe.FindString(page)
+	match := hrefRe.FindStringSubmatch(tag)
+	if len(match) < 2 {
+		return ""
+	}
+	base, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+	amp, err := base.Parse(match[1])
+	if err != nil {
+		return ""
+	}
+	return amp.String()
+}
+
+func archivedVersion(link string, httpClient HTTPClient) string {
+	resp, err := httpClient.Get(waybackURL + "?url=" + url.QueryEscape(link))
+	if err != nil {
+		return ""
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return ""
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxPageSize))
+	if err != nil {
+		return ""
+	}
+	var wayback WaybackResponse
+	if json.Unmarshal(body, &wayback) != nil {
+		return ""
+	}
+	if !wayback.ArchivedSnapshots.Closest.Available {
+		return ""
+	}
+	return wayback.ArchivedSnapshots.Closest.URL
+}
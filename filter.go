@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+type Filtered struct {
+	sink   Sink
+	filter *AggregateFilter
+}
+
+func parseSinkFilters(text string) (map[string]*AggregateFilter, error) {
+	filters := make(map[string]*AggregateFilter)
+	for _, rule := range strings.Fields(text) {
+		name, query, found := strings.Cut(rule, "?")
+		if !found || name == "" {
+			return nil, fmt.Errorf("invalid sink filter %q, expected e.g. 'discord?tags=security,cve'", rule)
+		}
+		values, err := url.ParseQuery(query)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sink filter %q: %w", rule, err)
+		}
+		if values.Has("page") {
+			return nil, fmt.Errorf("invalid sink filter %q: page is not a filter", rule)
+		}
+		filter, err := aggregateFilter(values)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sink filter %q: %w", rule, err)
+		}
+		filters[strings.ToLower(name)] = filter
+	}
+	return filters, nil
+}
+
+func filterSinks(all []Sink, filters map[string]*AggregateFilter) ([]Sink, error) {
+	used := make(map[string]bool)
+	for i, sink := range all {
+		key := sink.Key()
+		filter, ok := filters[key]
+		if !ok {
+			continue
+		}
+		used[key] = true
+		all[i] = &Filtered{sink: sink, filter: filter}
+	}
+	for name := range filters {
+		if !used[name] {
+			return nil, fmt.Errorf("no %s sink to filter, it is not configured", name)
+		}
+	}
+	return all, nil
+}
+
+func (f *Filtered) Name() string {
+	return f.sink.Name()
+}
+
+func (f *Filtered) Key() string {
+	return f.sink.Key()
+}
+
+func (f *Filtered) Deliver(entry *Entry) error {
+	item := aggregateItem(entry)
+	if !f.filter.matches(&item) {
+		if logger != nil {
+			logger.Printf("Not delivering '%s' to %s, it doesn't match the sink filter", entry.Item.Title, f.sink.Name())
+		}
+		return nil
+	}
+	return f.sink.Deliver(entry)
+}
+
+func (f *Filtered) Flush() error {
+	if flusher, ok := f.sink.(Flusher); ok {
+		return flusher.Flush()
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type Point struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+var datelineRe = regexp.MustCompile(`^(\p{Lu}[\p{Lu}.'\- ]*\p{Lu})((?:,\s*\p{Lu}[\p{L}.]*(?: \p{Lu}[\p{L}.]*)*)?)\s*(?:\([^)]{1,40}\)\s*)?[—–-]{1,2}\s`)
+
+func (i *Item) point() *Point {
+	lat, lon := i.GeoLat, i.GeoLong
+	if fields := strings.Fields(i.GeoPoint); len(fields) == 2 {
+		lat, lon = fields[0], fields[1]
+	}
+	latitude, err := strconv.ParseFloat(strings.TrimSpace(lat), 64)
+	if err != nil || latitude < -90 || latitude > 90 {
+		return nil
+	}
+	longitude, err := strconv.ParseFloat(strings.TrimSpace(lon), 64)
+	if err != nil || longitude < -180 || longitude > 180 {
+		return nil
+	}
+	return &Point{Lat: latitude, Lon: longitude}
+}
+
+func (e *Entry) place() string {
+	for _, text := range []string{e.Description, e.Content} {
+		if place := dateline(text); place != "" {
+			return place
+		}
+	}
+	return ""
+}
+
+func dateline(text string) string {
+	match := datelineRe.FindStringSubmatch(strings.TrimSpace(text))
+	if match == nil || len([]rune(match[1])) < 3 {
+		return ""
+	}
+	return capitalize(match[1]) + match[2]
+}
+
+func capitalize(text string) string {
+	runes := []rune(strings.ToLower(text))
+	for i := range runes {
+		if i == 0 || !unicode.IsLetter(runes[i-1]) && runes[i-1] != '\'' {
+			runes[i] = unicode.ToUpper(runes[i])
+		}
+	}
+	return string(runes)
+}
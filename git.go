@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+type GitRepo struct {
+	dir     string
+	message *template.Template
+	push    bool
+	pending map[string]int
+	mutex   sync.Mutex
+}
+
+func newGitRepo(dir string, message string, push bool) (*GitRepo, error) {
+	tmpl, err := template.New("message").Parse(message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse git commit message template: %w", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err != nil {
+		return nil, fmt.Errorf("%s is not a git repository", dir)
+	}
+	return &GitRepo{dir: dir, message: tmpl, push: push, pending: make(map[string]int)}, nil
+}
+
+func (g *GitRepo) Name() string {
+	return "git"
+}
+
+func (g *GitRepo) Key() string {
+	return "git"
+}
+
+func (g *GitRepo) Deliver(entry *Entry) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	file := hostname(entry.Feed)
+	if file == "" {
+		file = "items"
+	}
+	file += ".md"
+	line := "-"
+	if date := entry.date(); date != "" {
+		line += " " + date
+	}
+	title := strip(entry.Item.Title)
+	if title == "" {
+		title = entry.Item.Link
+	}
+	if entry.Item.Link != "" {
+		line += fmt.Sprintf(" [%s](%s)", title, entry.Item.Link)
+	} else {
+		line += " " + title
+	}
+	f, err := os.OpenFile(filepath.Join(g.dir, file), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = fmt.Fprintln(f, line)
+	if err != nil {
+		return err
+	}
+	g.pending[file]++
+	return nil
+}
+
+func (g *GitRepo) Flush() error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if len(g.pending) == 0 {
+		return nil
+	}
+	var files []string
+	count := 0
+	for file, n := range g.pending {
+		files = append(files, file)
+		count += n
+	}
+	sort.Strings(files)
+	feeds := make([]string, len(files))
+	for i, file := range files {
+		feeds[i] = strings.TrimSuffix(file, ".md")
+	}
+	var message bytes.Buffer
+	err := g.message.Execute(&message, struct {
+		Count int
+		Feeds string
+		Time  string
+	}{
+		Count: count,
+		Feeds: strings.Join(feeds, ", "),
+		Time:  clock.Now().Format("2006-01-02 15:04:05"),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to render git commit message: %w", err)
+	}
+	err = g.git(append([]string{"add", "--"}, files...)...)
+	if err == nil {
+		err = g.git("commit", "--quiet", "-m", message.String())
+	}
+	if err != nil {
+		return err
+	}
+	g.pending = make(map[string]int)
+	if logger != nil {
+		logger.Printf("Committed %d items to git repository %s", count, g.dir)
+	}
+	if g.push {
+		return g.git("push", "--quiet")
+	}
+	return nil
+}
+
+func (g *GitRepo) git(args ...string) error {
+	cmd := exec.Command("git", append([]string{"-C", g.dir}, args...)...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git %s failed: %v: %s", args[0], err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
@@ -3,8 +3,20 @@
 
 module rssp
 
-go 1.24
+go 1.24.0
 
 toolchain go1.24.4
 
-require golang.org/x/text v0.26.0
+require (
+	github.com/lib/pq v1.12.3
+	golang.org/x/crypto v0.47.0
+	golang.org/x/net v0.49.0
+	golang.org/x/text v0.33.0
+	google.golang.org/grpc v1.80.0
+	google.golang.org/protobuf v1.36.11
+)
+
+require (
+	golang.org/x/sys v0.40.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20260120221211-b8f7ae30c516 // indirect
+)
@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+
+	"rssp/api"
+)
+
+const subscriberBuffer = 64
+
+type Hub struct {
+	mutex       sync.Mutex
+	subscribers map[*subscriber]bool
+}
+
+type subscriber struct {
+	filter *api.FilterRequest
+	items  chan *api.Item
+	done   <-chan struct{}
+	kicked chan struct{}
+}
+
+type itemsServer struct {
+	api.UnimplementedItemsServer
+	hub *Hub
+}
+
+func newGRPCServer(cert string, key string, ca string, hub *Hub) (*grpc.Server, error) {
+	certificate, err := tls.LoadX509KeyPair(cert, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load gRPC certificate: %w", err)
+	}
+	authority, err := os.ReadFile(ca)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read gRPC client CA: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(authority) {
+		return nil, fmt.Errorf("no certificates found in %s", ca)
+	}
+	config := &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}
+	server := grpc.NewServer(grpc.Creds(credentials.NewTLS(config)))
+	api.RegisterItemsServer(server, &itemsServer{hub: hub})
+	return server, nil
+}
+
+func (s *itemsServer) Subscribe(filter *api.FilterRequest, stream api.Items_SubscribeServer) error {
+	sub := &subscriber{
+		filter: filter,
+		items:  make(chan *api.Item, subscriberBuffer),
+		done:   stream.Context().Done(),
+		kicked: make(chan struct{}),
+	}
+	s.hub.add(sub)
+	defer s.hub.remove(sub)
+	if logger != nil {
+		logger.Printf("gRPC subscriber connected")
+	}
+	for {
+		select {
+		case <-sub.done:
+			return nil
+		case <-sub.kicked:
+			return status.Errorf(codes.ResourceExhausted, "more than %d items are waiting, the subscriber is too slow", subscriberBuffer)
+		case item := <-sub.items:
+			if err := stream.Send(item); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (h *Hub) add(sub *subscriber) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if h.subscribers == nil {
+		h.subscribers = make(map[*subscriber]bool)
+	}
+	h.subscribers[sub] = true
+}
+
+func (h *Hub) remove(sub *subscriber) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	delete(h.subscribers, sub)
+}
+
+func (h *Hub) kick(sub *subscriber) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if !h.subscribers[sub] {
+		return
+	}
+	delete(h.subscribers, sub)
+	close(sub.kicked)
+	if logger != nil {
+		logger.Printf("Disconnected a gRPC subscriber that has %d items waiting", subscriberBuffer)
+	}
+}
+
+func (h *Hub) Name() string {
+	return "gRPC"
+}
+
+func (h *Hub) Key() string {
+	return "grpc"
+}
+
+func (h *Hub) Deliver(entry *Entry) error {
+	item := message(entry)
+	h.mutex.Lock()
+	var targets []*subscriber
+	for sub := range h.subscribers {
+		if wanted(sub.filter, item) {
+			targets = append(targets, sub)
+		}
+	}
+	h.mutex.Unlock()
+	for _, sub := range targets {
+		select {
+		case sub.items <- item:
+		case <-sub.done:
+		default:
+			h.kick(sub)
+		}
+	}
+	return nil
+}
+
+func message(entry *Entry) *api.Item {
+	record := entry.Record()
+	item := &api.Item{
+		Schema:      record.Schema,
+		Feed:        record.Feed,
+		Channel:     record.Channel,
+		Language:    record.Language,
+		Title:       record.Title,
+		Link:        record.Link,
+		Image:       record.Image,
+		Description: record.Description,
+		Content:     record.Content,
+		Summary:     record.Summary,
+		Published:   record.Published,
+		Guid:        record.GUID,
+		Archived:    record.Archived,
+	}
+	if record.Entities != nil {
+		item.People = record.Entities.People
+		item.Companies = record.Entities.Companies
+		item.Products = record.Entities.Products
+	}
+	return item
+}
+
+func wanted(filter *api.FilterRequest, item *api.Item) bool {
+	if len(filter.Feeds) > 0 {
+		found := false
+		for _, feed := range filter.Feeds {
+			if feed == item.Feed {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if filter.Language != "" && !strings.HasPrefix(strings.ToLower(item.Language), strings.ToLower(filter.Language)) {
+		return false
+	}
+	if len(filter.Keywords) == 0 {
+		return true
+	}
+	text := strings.ToLower(strings.Join([]string{item.Title, item.Description, item.Content, item.Summary}, " "))
+	for _, keyword := range filter.Keywords {
+		if strings.Contains(text, strings.ToLower(keyword)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+type Heartbeat struct {
+	polled      atomic.Int64
+	added       atomic.Int64
+	filtered    atomic.Int64
+	failed      atomic.Int64
+	undelivered atomic.Int64
+}
+
+var heartbeat Heartbeat
+
+func (h *Heartbeat) report(interval time.Duration) string {
+	report := fmt.Sprintf("Heartbeat: %d feeds polled, %d new items, %d filtered, %d errors in the last %s",
+		h.polled.Swap(0), h.added.Swap(0), h.filtered.Swap(0), h.failed.Swap(0), interval)
+	if journal != nil {
+		report += fmt.Sprintf(", %d failed deliveries, %d pending", h.undelivered.Swap(0), journal.count())
+	}
+	return report
+}
+
+func (h *Heartbeat) run(interval time.Duration) {
+	for clock.Sleep(interval) {
+		logger.Print(h.report(interval))
+	}
+}
@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type PostCycle struct {
+	command string
+	batch   []Record
+	mutex   sync.Mutex
+}
+
+func (p *PostCycle) Name() string {
+	return "post-cycle command"
+}
+
+func (p *PostCycle) Key() string {
+	return "post-cycle"
+}
+
+func (p *PostCycle) Deliver(entry *Entry) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.batch = append(p.batch, entry.Record())
+	return nil
+}
+
+func (p *PostCycle) Flush() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if len(p.batch) == 0 {
+		return nil
+	}
+	input, err := json.Marshal(p.batch)
+	if err != nil {
+		return err
+	}
+	cmd := shell(p.command)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Env = append(os.Environ(), "RSSP_COUNT="+strconv.Itoa(len(p.batch)))
+	output, err := cmd.CombinedOutput()
+	if logger != nil && len(output) > 0 {
+		logger.Printf("Post-cycle command output: %s", strings.TrimSpace(string(output)))
+	}
+	if err != nil {
+		return fmt.Errorf("post-cycle command failed: %w", err)
+	}
+	if logger != nil {
+		logger.Printf("Post-cycle command processed %d new items", len(p.batch))
+	}
+	p.batch = nil
+	return nil
+}
+
+func shell(command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.Command("cmd", "/C", command)
+	}
+	return exec.Command("sh", "-c", command)
+}
@@ -4,13 +4,42 @@
 package main
 
 import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
 	"errors"
+	"fmt"
 	"io"
+	"log"
+	"math/big"
+	"net"
 	"net/http"
+	"net/http/httptest"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"rssp/api"
 )
 
 func TestFetchFeedWithSuccess(t *testing.T) {
@@ -37,7 +66,7 @@ func TestFetchFeedWithSuccess(t *testing.T) {
 	}
 	client = mockClient
 
-	feed, err := fetchFeed("https://test.com/feed.xml")
+	feed, err := fetchFeed(context.Background(), "https://test.com/feed.xml")
 	if err != nil {
 		t.Fatalf("fetchFeed returned error: %v", err)
 	}
@@ -47,6 +76,84 @@ func TestFetchFeedWithSuccess(t *testing.T) {
 	}
 }
 
+func TestFetchFeedSkipsParsingUnchangedBody(t *testing.T) {
+	originalLogger := logger
+	body := `<rss><channel><title>Same</title><item><guid>1</guid></item></channel></rss>`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, body)
+	}))
+	defer server.Close()
+	var logs strings.Builder
+	logger = log.New(&logs, "", 0)
+	defer func() { logger = originalLogger }()
+	first, err := fetchFeed(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("fetchFeed returned error: %v", err)
+	}
+	first.Channel.Title = "Changed by caller"
+	second, err := fetchFeed(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("fetchFeed returned error: %v", err)
+	}
+	if strings.Count(logs.String(), "Parsing RSS XML") != 1 || !strings.Contains(logs.String(), "has not changed") {
+		t.Errorf("expected the second poll not to be parsed, log was %q", logs.String())
+	}
+	if second.Channel.Title != "Same" || len(second.Channel.Items) != 1 {
+		t.Errorf("expected the previous feed, got %+v", second.Channel)
+	}
+	body = `<rss><channel><title>Different</title></channel></rss>`
+	third, _ := fetchFeed(context.Background(), server.URL)
+	if third == nil || third.Channel.Title != "Different" {
+		t.Errorf("expected a changed body to be parsed, got %+v", third)
+	}
+}
+
+func TestFetchFeedExplainsWebPagesAndSuggestsTheirFeeds(t *testing.T) {
+	page := `<!DOCTYPE html><html><head><title>Blog</title>` +
+		`<link rel="alternate" type="application/rss+xml" title="RSS" href="/feed.xml">` +
+		`<link rel="alternate" type="application/atom+xml" href="https://cdn.example.com/atom.xml">` +
+		`</head><body><p>Hello</p></body></html>`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		if r.URL.Path == "/bare" {
+			fmt.Fprint(w, `<html><body>No feeds here</body></html>`)
+			return
+		}
+		fmt.Fprint(w, page)
+	}))
+	defer server.Close()
+	_, err := fetchFeed(context.Background(), server.URL+"/blog")
+	if !errors.Is(err, ErrParse) {
+		t.Fatalf("expected a parse error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "is a web page, not a feed") ||
+		!strings.Contains(err.Error(), server.URL+"/feed.xml and https://cdn.example.com/atom.xml") {
+		t.Errorf("expected the advertised feeds to be suggested, got %v", err)
+	}
+	_, err = fetchFeed(context.Background(), server.URL+"/bare")
+	if err == nil || !strings.Contains(err.Error(), "does not advertise any feed") {
+		t.Errorf("expected a hint to pass the feed URL itself, got %v", err)
+	}
+}
+
+func TestFetchFeedParsesFeedsThatLookLikeWebPages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<!-- generated by the blog engine --><rss version="2.0"><channel><title>Commented</title>`+
+			`<item><title>First</title><link>https://example.com/1</link></item></channel></rss>`)
+	}))
+	defer server.Close()
+	if !webPage([]byte(`<!-- generated by the blog engine --><rss version="2.0"></rss>`)) {
+		t.Fatal("expected the leading comment to be sniffed as HTML")
+	}
+	rss, err := fetchFeed(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("expected the feed to be parsed, got %v", err)
+	}
+	if rss.Channel.Title != "Commented" || len(rss.Channel.Items) != 1 {
+		t.Errorf("unexpected feed %+v", rss.Channel)
+	}
+}
+
 func TestFetchFeedWithHTTPError(t *testing.T) {
 	oldClient := client
 	defer func() { client = oldClient }()
@@ -63,7 +170,7 @@ func TestFetchFeedWithHTTPError(t *testing.T) {
 	}
 	client = mockClient
 
-	_, err := fetchFeed("https://test.com/404")
+	_, err := fetchFeed(context.Background(), "https://test.com/404")
 	if err == nil {
 		t.Error("expected error for 404 response, got nil")
 	}
@@ -85,7 +192,7 @@ func TestFetchFeedWithNetworkError(t *testing.T) {
 	}
 	client = mockClient
 
-	_, err := fetchFeed("https://test.com/error")
+	_, err := fetchFeed(context.Background(), "https://test.com/error")
 	if err == nil {
 		t.Error("expected network error, got nil")
 	}
@@ -106,7 +213,7 @@ func TestFetchFeedWithInvalidResponseBody(t *testing.T) {
 	}
 	client = mockClient
 
-	_, err := fetchFeed("https://test.com/invalid")
+	_, err := fetchFeed(context.Background(), "https://test.com/invalid")
 	if err == nil {
 		t.Error("expected error for invalid response body, got nil")
 	}
@@ -131,7 +238,7 @@ func TestFetchFeedBodyReadError(t *testing.T) {
 	}
 	client = mockClient
 
-	_, err := fetchFeed("https://test.com/close-error")
+	_, err := fetchFeed(context.Background(), "https://test.com/close-error")
 	if err == nil {
 		t.Error("expected error when body read fails")
 	}
@@ -206,7 +313,7 @@ func TestIntegrationCompactOutputWithXMLFile(t *testing.T) {
 	}
 
 	for _, item := range feed.Channel.Items {
-		printItem("file://"+feedPath, &item, feed.Channel.Title)
+		printItem("file://"+feedPath, &item, &feed.Channel)
 	}
 
 	file.Close()
@@ -286,3 +393,1854 @@ func TestExtractContentWithNetworkError(t *testing.T) {
 		t.Errorf("extractContent should return empty string on network error: got %q", result)
 	}
 }
+
+func TestExtractEntitiesWithOpenAI(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/chat/completions" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"{\"people\":[\"Linus Torvalds\"],\"companies\":[\"Red Hat\"],\"products\":[\"Linux\"]}"}}]}`))
+	}))
+	defer server.Close()
+	originalURL := openaiURL
+	openaiURL = server.URL
+	defer func() { openaiURL = originalURL }()
+	os.Setenv("OPENAI_API_KEY", "test-key")
+	defer os.Unsetenv("OPENAI_API_KEY")
+	entities := extractEntities(context.Background(), "Linus Torvalds talked about Linux at Red Hat", "")
+	if entities == nil {
+		t.Fatal("expected entities, got nil")
+	}
+	if len(entities.People) != 1 || entities.People[0] != "Linus Torvalds" {
+		t.Errorf("unexpected people: %v", entities.People)
+	}
+	if len(entities.Companies) != 1 || entities.Companies[0] != "Red Hat" {
+		t.Errorf("unexpected companies: %v", entities.Companies)
+	}
+	if len(entities.Products) != 1 || entities.Products[0] != "Linux" {
+		t.Errorf("unexpected products: %v", entities.Products)
+	}
+}
+
+func TestExtractEntitiesWithMalformedResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"I cannot do that"}}]}`))
+	}))
+	defer server.Close()
+	originalURL := openaiURL
+	openaiURL = server.URL
+	defer func() { openaiURL = originalURL }()
+	os.Setenv("OPENAI_API_KEY", "test-key")
+	defer os.Unsetenv("OPENAI_API_KEY")
+	if entities := extractEntities(context.Background(), "some text", ""); entities != nil {
+		t.Errorf("expected nil entities for malformed response, got %v", entities)
+	}
+}
+
+func TestDescribeImageSendsImageURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !strings.Contains(string(body), `"image_url":{"url":"https://example.com/comic.png"}`) {
+			t.Errorf("request should contain the image URL, got %s", body)
+		}
+		w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":" A cat explains recursion. "}}]}`))
+	}))
+	defer server.Close()
+	originalURL := openaiURL
+	openaiURL = server.URL
+	defer func() { openaiURL = originalURL }()
+	os.Setenv("OPENAI_API_KEY", "test-key")
+	defer os.Unsetenv("OPENAI_API_KEY")
+	description := describeImage(context.Background(), "https://example.com/comic.png", "")
+	if description != "A cat explains recursion." {
+		t.Errorf("unexpected description: %q", description)
+	}
+}
+
+func TestTranscribeUploadsAudio(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/audio/transcriptions" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if r.FormValue("model") != "whisper-1" {
+			t.Errorf("expected whisper-1 model, got '%s'", r.FormValue("model"))
+		}
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			t.Fatalf("request should contain an audio file: %v", err)
+		}
+		defer file.Close()
+		audio, _ := io.ReadAll(file)
+		if string(audio) != "fake-mp3-bytes" || header.Filename != "ep1.mp3" {
+			t.Errorf("unexpected upload %s: %q", header.Filename, audio)
+		}
+		w.Write([]byte("Welcome to the show.\n"))
+	}))
+	defer server.Close()
+	originalURL := openaiURL
+	openaiURL = server.URL
+	defer func() { openaiURL = originalURL }()
+	originalMaxLength := maxLength
+	maxLength = 2000
+	defer func() { maxLength = originalMaxLength }()
+	os.Setenv("OPENAI_API_KEY", "test-key")
+	defer os.Unsetenv("OPENAI_API_KEY")
+	mockClient := &mockHTTPClient{
+		responses: map[string]*http.Response{
+			"https://example.com/ep1.mp3": {
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader("fake-mp3-bytes")),
+			},
+		},
+	}
+	transcript := transcribe(context.Background(), "https://example.com/ep1.mp3", mockClient)
+	if transcript != "Welcome to the show." {
+		t.Errorf("unexpected transcript: %q", transcript)
+	}
+}
+
+func TestExtractBasicContentRevalidatesCachedPage(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte("<html><body><article>Cached article</article></body></html>"))
+	}))
+	defer server.Close()
+	originalCacheDir := cacheDir
+	cacheDir = t.TempDir()
+	defer func() { cacheDir = originalCacheDir }()
+	originalMaxLength := maxLength
+	maxLength = 2000
+	defer func() { maxLength = originalMaxLength }()
+	first := extractBasicContent(server.URL+"/article", http.DefaultClient)
+	second := extractBasicContent(server.URL+"/article", http.DefaultClient)
+	if first != "Cached article" || second != "Cached article" {
+		t.Errorf("expected cached article twice, got %q and %q", first, second)
+	}
+	if requests != 2 {
+		t.Errorf("expected 2 requests, got %d", requests)
+	}
+}
+
+func TestExtractBasicContentSkipsCacheWithoutValidators(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html><body><article>Volatile</article></body></html>"))
+	}))
+	defer server.Close()
+	originalCacheDir := cacheDir
+	cacheDir = t.TempDir()
+	defer func() { cacheDir = originalCacheDir }()
+	extractBasicContent(server.URL+"/article", http.DefaultClient)
+	if page := loadPage(server.URL + "/article"); page != nil {
+		t.Error("pages without ETag or Last-Modified should not be cached")
+	}
+}
+
+func TestProcessWithOpenAIReusesSummaryForSameContent(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"RELEVANT: Short summary"}}]}`))
+	}))
+	defer server.Close()
+	originalURL := openaiURL
+	openaiURL = server.URL
+	defer func() { openaiURL = originalURL }()
+	originalCacheDir := cacheDir
+	cacheDir = t.TempDir()
+	defer func() { cacheDir = originalCacheDir }()
+	os.Setenv("OPENAI_API_KEY", "test-key")
+	defer os.Unsetenv("OPENAI_API_KEY")
+	for i := 0; i < 3; i++ {
+		summary, relevant := processWithOpenAI(context.Background(), "Syndicated article body about compilers", "compilers", "", "")
+		if !relevant || summary != "Short summary" {
+			t.Errorf("unexpected result %q, %v", summary, relevant)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 OpenAI call, got %d", calls)
+	}
+}
+
+func TestExtractFallbackUsesAMPVersion(t *testing.T) {
+	originalMaxLength := maxLength
+	maxLength = 2000
+	defer func() { maxLength = originalMaxLength }()
+	story := strings.Repeat("Full story text. ", 20)
+	mockClient := &mockHTTPClient{
+		responses: map[string]*http.Response{
+			"https://example.com/amp/news/1": {
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader("<html><body><article>" + story + "</article></body></html>")),
+			},
+		},
+		errors: map[string]error{
+			"https://example.com/news/1": errors.New("original article fetched twice"),
+		},
+	}
+	page := `<html><head><link href="/amp/news/1" rel="amphtml"></head><body>Subscribe to read</body></html>`
+	result := extractFallback("https://example.com/news/1", page, mockClient)
+	if result != strings.TrimSpace(story) {
+		t.Errorf("expected AMP article text, got %q", result)
+	}
+}
+
+func TestExtractFallbackRefusesNonHTTPAMPLink(t *testing.T) {
+	mockClient := &mockHTTPClient{
+		responses: map[string]*http.Response{
+			"file:///etc/passwd": {
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader("<html><body>root:x:0:0</body></html>")),
+			},
+		},
+	}
+	page := `<html><head><link rel="amphtml" href="file:///etc/passwd"></head></html>`
+	if result := extractFallback("https://example.com/news/2", page, mockClient); result != "" {
+		t.Errorf("expected non-http AMP link to be refused, got %q", result)
+	}
+}
+
+func TestExtractFallbackUsesWaybackSnapshot(t *testing.T) {
+	originalMaxLength := maxLength
+	maxLength = 2000
+	defer func() { maxLength = originalMaxLength }()
+	mockClient := &mockHTTPClient{
+		responses: map[string]*http.Response{
+			"https://archive.org/wayback/available?url=https%3A%2F%2Fexample.com%2Fgone": {
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(`{"archived_snapshots":{"closest":{"available":true,"url":"http://web.archive.org/web/2024/https://example.com/gone"}}}`)),
+			},
+			"http://web.archive.org/web/2024/https://example.com/gone": {
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader("<html><body><main>Archived copy</main></body></html>")),
+			},
+		},
+		errors: map[string]error{
+			"https://example.com/gone": errors.New("connection refused"),
+		},
+	}
+	result := extractFallback("https://example.com/gone", "", mockClient)
+	if result != "Archived copy" {
+		t.Errorf("expected archived text, got %q", result)
+	}
+}
+
+func TestArchiveReturnsSnapshotLocation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/save/https://example.com/story" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Location", "/web/20240101000000/https://example.com/story")
+		w.Write([]byte("<html>archived page</html>"))
+	}))
+	defer server.Close()
+	originalURL := archiveURL
+	archiveURL = server.URL + "/save/"
+	defer func() { archiveURL = originalURL }()
+	archived := archive(context.Background(), "https://example.com/story", http.DefaultClient)
+	if archived != server.URL+"/web/20240101000000/https://example.com/story" {
+		t.Errorf("unexpected archived URL '%s'", archived)
+	}
+}
+
+func TestArchiveIsRateLimited(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Location", "/web/1/x")
+	}))
+	defer server.Close()
+	originalURL := archiveURL
+	originalInterval := archiveInterval
+	archiveURL = server.URL + "/save/"
+	archiveInterval = 100 * time.Millisecond
+	defer func() {
+		archiveURL = originalURL
+		archiveInterval = originalInterval
+	}()
+	start := time.Now()
+	archive(context.Background(), "https://example.com/a", http.DefaultClient)
+	archive(context.Background(), "https://example.com/b", http.DefaultClient)
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("two submissions should be at least 100ms apart, took %v", elapsed)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if archived := archive(ctx, "https://example.com/c", http.DefaultClient); archived != "" {
+		t.Errorf("expected a canceled submission to give up, got %q", archived)
+	}
+}
+
+func TestWallabagSavesLinkWithTags(t *testing.T) {
+	saved := ""
+	tags := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/oauth/v2/token":
+			if r.FormValue("username") != "reader" || r.FormValue("grant_type") != "password" {
+				t.Errorf("unexpected token request: %v", r.Form)
+			}
+			w.Write([]byte(`{"access_token":"secret-token"}`))
+		case "/api/entries.json":
+			if r.Header.Get("Authorization") != "Bearer secret-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			saved = r.FormValue("url")
+			tags = r.FormValue("tags")
+			w.Write([]byte(`{"id":1}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+	sink := &Wallabag{url: server.URL, clientID: "id", clientSecret: "secret", username: "reader", password: "pass"}
+	entry := &Entry{
+		Item:     &Item{Title: "Story", Link: "https://example.com/story"},
+		Entities: &Entities{Companies: []string{"Acme"}, Products: []string{"Rocket"}},
+	}
+	err := sink.Deliver(entry)
+	if err != nil {
+		t.Fatalf("Deliver returned error: %v", err)
+	}
+	if saved != "https://example.com/story" {
+		t.Errorf("expected link to be saved, got '%s'", saved)
+	}
+	if tags != "Acme,Rocket" {
+		t.Errorf("expected tags 'Acme,Rocket', got '%s'", tags)
+	}
+}
+
+func TestWallabagReauthenticatesOnExpiredToken(t *testing.T) {
+	tokens := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth/v2/token" {
+			tokens++
+			w.Write([]byte(`{"access_token":"fresh"}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+	sink := &Wallabag{url: server.URL, token: "expired"}
+	err := sink.Deliver(&Entry{Item: &Item{Link: "https://example.com/a"}})
+	if err != nil {
+		t.Fatalf("Deliver returned error: %v", err)
+	}
+	if tokens != 1 {
+		t.Errorf("expected one re-authentication, got %d", tokens)
+	}
+}
+
+func TestGitRepoCommitsAfterFlush(t *testing.T) {
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"config", "user.email", "rssp@example.com"},
+		{"config", "user.name", "rssp"},
+		{"config", "commit.gpgsign", "false"},
+	} {
+		if output, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v: %s", args, err, output)
+		}
+	}
+	repo, err := newGitRepo(dir, "Add {{.Count}} items from {{.Feeds}}", false)
+	if err != nil {
+		t.Fatalf("newGitRepo returned error: %v", err)
+	}
+	repo.Deliver(newEntry("https://example.com/feed", &Channel{}, &Item{Title: "One", Link: "https://example.com/1", PubDate: "Mon, 15 Mar 2023 10:30:00 GMT"}))
+	repo.Deliver(newEntry("https://example.com/feed", &Channel{}, &Item{Title: "Two", Link: "https://example.com/2"}))
+	err = repo.Flush()
+	if err != nil {
+		t.Fatalf("Flush returned error: %v", err)
+	}
+	err = repo.Flush()
+	if err != nil {
+		t.Fatalf("empty Flush returned error: %v", err)
+	}
+	log, err := exec.Command("git", "-C", dir, "log", "--format=%s").CombinedOutput()
+	if err != nil {
+		t.Fatalf("git log failed: %v: %s", err, log)
+	}
+	if strings.TrimSpace(string(log)) != "Add 2 items from example.com" {
+		t.Errorf("unexpected git log: %q", log)
+	}
+	content, _ := os.ReadFile(filepath.Join(dir, "example.com.md"))
+	expected := "- 15-03-2023 [One](https://example.com/1)\n- [Two](https://example.com/2)\n"
+	if string(content) != expected {
+		t.Errorf("unexpected file content: %q", content)
+	}
+}
+
+func TestNewGitRepoRejectsPlainDirectory(t *testing.T) {
+	if _, err := newGitRepo(t.TempDir(), "msg", false); err == nil {
+		t.Error("newGitRepo should fail outside of a git repository")
+	}
+}
+
+func TestBucketUploadsJSONLinesOnFlush(t *testing.T) {
+	var uploaded []string
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=key/") {
+			t.Errorf("unexpected request %s with %q", r.Method, r.Header.Get("Authorization"))
+		}
+		uploaded = append(uploaded, r.URL.Path)
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+	}))
+	defer server.Close()
+	bucket := &Bucket{endpoint: server.URL, bucket: "news", prefix: "daily/", region: "auto", accessKey: "key", secretKey: "secret"}
+	bucket.Flush()
+	if len(uploaded) != 0 {
+		t.Errorf("nothing should be uploaded without items, got %v", uploaded)
+	}
+	bucket.Deliver(&Entry{Feed: "https://example.com/feed", Item: &Item{Title: "One", GUID: "1"}})
+	bucket.Deliver(&Entry{Feed: "https://example.com/feed", Item: &Item{Title: "Two", GUID: "2"}})
+	err := bucket.Flush()
+	if err != nil {
+		t.Fatalf("Flush returned error: %v", err)
+	}
+	if len(uploaded) != 1 || !strings.HasPrefix(uploaded[0], "/news/daily/") || !strings.HasSuffix(uploaded[0], ".jsonl") {
+		t.Fatalf("unexpected uploads %v", uploaded)
+	}
+	lines := strings.Split(strings.TrimSpace(body), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[1], `"title":"Two"`) {
+		t.Errorf("unexpected JSONL body %q", body)
+	}
+	bucket.Flush()
+	if len(uploaded) != 1 {
+		t.Error("the buffer should be empty after an upload")
+	}
+}
+
+func TestMQTTPublishesItemToTopic(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer listener.Close()
+	received := make(chan []byte, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		header := make([]byte, 2)
+		io.ReadFull(conn, header)
+		connect := make([]byte, header[1])
+		io.ReadFull(conn, connect)
+		conn.Write([]byte{0x20, 0x02, 0x00, 0x00})
+		io.ReadFull(conn, header)
+		publish := make([]byte, header[1])
+		io.ReadFull(conn, publish)
+		received <- append(header, publish...)
+	}()
+	broker, err := newMQTT("tcp://"+listener.Addr().String(), "news/{{.Host}}")
+	if err != nil {
+		t.Fatalf("newMQTT returned error: %v", err)
+	}
+	err = broker.Deliver(&Entry{Feed: "https://example.com/feed", Item: &Item{Title: "Hi"}})
+	if err != nil {
+		t.Fatalf("Deliver returned error: %v", err)
+	}
+	packet := <-received
+	if packet[0] != 0x30 {
+		t.Errorf("expected PUBLISH packet, got type %x", packet[0])
+	}
+	topicLength := int(packet[2])<<8 | int(packet[3])
+	topic := string(packet[4 : 4+topicLength])
+	if topic != "news/example.com" {
+		t.Errorf("unexpected topic '%s'", topic)
+	}
+	if !strings.Contains(string(packet[4+topicLength:]), `"title":"Hi"`) {
+		t.Errorf("unexpected payload %s", packet[4+topicLength:])
+	}
+}
+
+func TestDiscordBatchesEmbedsByTen(t *testing.T) {
+	var batches []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var message DiscordMessage
+		json.NewDecoder(r.Body).Decode(&message)
+		batches = append(batches, len(message.Embeds))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	discord := &Discord{webhook: server.URL}
+	for i := 0; i < 12; i++ {
+		err := discord.Deliver(&Entry{Feed: "https://example.com/feed", Item: &Item{Title: fmt.Sprintf("Item %d", i)}})
+		if err != nil {
+			t.Fatalf("Deliver returned error: %v", err)
+		}
+	}
+	if len(batches) != 1 || batches[0] != 10 {
+		t.Errorf("expected one full batch of 10 during delivery, got %v", batches)
+	}
+	err := discord.Flush()
+	if err != nil {
+		t.Fatalf("Flush returned error: %v", err)
+	}
+	if len(batches) != 2 || batches[1] != 2 {
+		t.Errorf("expected remaining 2 embeds on flush, got %v", batches)
+	}
+}
+
+func TestDiscordSplitsLongEmbedsAndDropsRejectedOnes(t *testing.T) {
+	var sizes []int
+	reject := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var message DiscordMessage
+		json.NewDecoder(r.Body).Decode(&message)
+		size := 0
+		for _, embed := range message.Embeds {
+			size += embed.size()
+		}
+		if reject || size > discordLimit {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		sizes = append(sizes, len(message.Embeds))
+	}))
+	defer server.Close()
+	discord := &Discord{webhook: server.URL}
+	for i := 0; i < 5; i++ {
+		discord.Deliver(&Entry{Feed: "https://example.com/feed", Item: &Item{Title: fmt.Sprintf("Item %d", i)}, Summary: strings.Repeat("x", 5000)})
+	}
+	if err := discord.Flush(); err != nil {
+		t.Fatalf("Flush returned error: %v", err)
+	}
+	if len(sizes) != 5 {
+		t.Errorf("expected every long embed in its own message, got %v", sizes)
+	}
+	reject = true
+	discord.Deliver(&Entry{Feed: "https://example.com/feed", Item: &Item{Title: "Rejected"}})
+	if err := discord.Flush(); err == nil {
+		t.Error("expected the rejection to be reported")
+	}
+	if len(discord.embeds) != 0 {
+		t.Errorf("expected rejected embeds to be dropped, got %d", len(discord.embeds))
+	}
+}
+
+func TestDiscordEmbedFields(t *testing.T) {
+	var message DiscordMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&message)
+	}))
+	defer server.Close()
+	discord := &Discord{webhook: server.URL}
+	discord.Deliver(newEntry(
+		"https://example.com/feed",
+		&Channel{Title: "Example"},
+		&Item{Title: "<b>Bold</b> news", Link: "https://example.com/1", Description: "Body", PubDate: "Mon, 15 Mar 2023 10:30:00 GMT"},
+	))
+	discord.Flush()
+	if len(message.Embeds) != 1 {
+		t.Fatalf("expected 1 embed, got %d", len(message.Embeds))
+	}
+	embed := message.Embeds[0]
+	if embed.Title != "Bold news" || embed.URL != "https://example.com/1" || embed.Description != "Body" {
+		t.Errorf("unexpected embed %+v", embed)
+	}
+	if embed.Footer == nil || embed.Footer.Text != "Example" {
+		t.Errorf("unexpected footer %+v", embed.Footer)
+	}
+	if embed.Timestamp != "2023-03-15T10:30:00Z" {
+		t.Errorf("unexpected timestamp %s", embed.Timestamp)
+	}
+}
+
+func TestWebhookSendsStableIdempotencyKey(t *testing.T) {
+	var keys []string
+	var record Record
+	failures := 1
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		json.NewDecoder(r.Body).Decode(&record)
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+	webhook := &Webhook{url: server.URL}
+	entry := newEntry("https://example.com/feed", &Channel{Title: "Example"}, &Item{Title: "News", GUID: "42"})
+	if err := webhook.Deliver(entry); err == nil {
+		t.Error("expected a 502 to fail the delivery")
+	}
+	if err := webhook.Deliver(entry); err != nil {
+		t.Fatalf("Deliver returned error: %v", err)
+	}
+	other := newEntry("https://example.org/feed", &Channel{Title: "Other"}, &Item{Title: "News", GUID: "42"})
+	webhook.Deliver(other)
+	if len(keys) != 3 || keys[0] == "" || keys[0] != keys[1] || keys[1] == keys[2] {
+		t.Errorf("expected a retry to reuse the key and another feed to get its own, got %v", keys)
+	}
+	if record.Title != "News" || record.Feed != "https://example.org/feed" {
+		t.Errorf("expected the item as a JSON record, got %+v", record)
+	}
+}
+
+func TestWebhookSignsPayloadWithSecret(t *testing.T) {
+	var signature string
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signature = r.Header.Get("X-RSSP-Signature")
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+	entry := newEntry("https://example.com/feed", &Channel{}, &Item{Title: "News", GUID: "42"})
+	if err := (&Webhook{url: server.URL, secret: "s3cret"}).Deliver(entry); err != nil {
+		t.Fatalf("Deliver returned error: %v", err)
+	}
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write(body)
+	if expected := "sha256=" + hex.EncodeToString(mac.Sum(nil)); signature != expected {
+		t.Errorf("expected signature %s, got %s", expected, signature)
+	}
+	(&Webhook{url: server.URL}).Deliver(entry)
+	if signature != "" {
+		t.Errorf("expected no signature without a secret, got %s", signature)
+	}
+}
+
+func TestWebhookPostsBatchesOfItems(t *testing.T) {
+	originalClock := clock
+	fake := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	clock = fake
+	defer func() { clock = originalClock }()
+	var batches [][]Record
+	var keys []string
+	down := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		if down {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var batch []Record
+		json.NewDecoder(r.Body).Decode(&batch)
+		batches = append(batches, batch)
+	}))
+	defer server.Close()
+	webhook := &Webhook{url: server.URL, batch: 3, interval: time.Minute}
+	for i := 0; i < 4; i++ {
+		entry := newEntry("https://example.com/feed", &Channel{}, &Item{Title: fmt.Sprintf("Item %d", i), GUID: strconv.Itoa(i)})
+		if err := webhook.Deliver(entry); err != nil {
+			t.Fatalf("Deliver returned error: %v", err)
+		}
+	}
+	if len(batches) != 1 || len(batches[0]) != 3 || batches[0][2].Title != "Item 2" {
+		t.Fatalf("expected a full batch of 3, got %v", batches)
+	}
+	webhook.Flush()
+	if len(batches) != 1 {
+		t.Errorf("expected the partial batch to wait for the flush interval, got %d batches", len(batches))
+	}
+	fake.now = fake.now.Add(time.Minute)
+	down = true
+	if err := webhook.Flush(); err == nil {
+		t.Error("expected a failed post to be reported")
+	}
+	down = false
+	if err := webhook.Flush(); err != nil {
+		t.Fatalf("Flush returned error: %v", err)
+	}
+	if len(batches) != 2 || len(batches[1]) != 1 || batches[1][0].Title != "Item 3" {
+		t.Errorf("expected the remaining item after the interval, got %v", batches)
+	}
+	if len(keys) != 3 || keys[1] != keys[2] || keys[0] == keys[1] {
+		t.Errorf("expected a retried batch to keep its key, got %v", keys)
+	}
+}
+
+func TestShutdownFlushesBufferedSinks(t *testing.T) {
+	originalSinks := sinks
+	originalQueues := queues
+	originalShutdown, originalStop := shutdown, stop
+	defer func() {
+		sinks = originalSinks
+		queues = originalQueues
+		shutdown, stop = originalShutdown, originalStop
+	}()
+	var posted []Record
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var batch []Record
+		json.NewDecoder(r.Body).Decode(&batch)
+		posted = append(posted, batch...)
+	}))
+	defer server.Close()
+	webhook := &Webhook{url: server.URL, batch: 10, interval: time.Hour, sent: clock.Now()}
+	sinks = []Sink{webhook}
+	startQueues()
+	shutdown, stop = context.WithCancel(context.Background())
+	deliver(newEntry("https://example.com/feed", &Channel{}, &Item{Title: "Buffered", GUID: "1"}))
+	if !drain(time.Second) || len(posted) != 0 {
+		t.Fatalf("expected the partial batch to wait for the flush interval, got %d", len(posted))
+	}
+	stop()
+	if !drain(5*time.Second) || len(posted) != 1 || posted[0].Title != "Buffered" {
+		t.Errorf("expected the partial batch to be posted on shutdown, got %v", posted)
+	}
+}
+
+func TestWebhookLimitsConcurrentPosts(t *testing.T) {
+	var mutex sync.Mutex
+	active, peak, posted := 0, 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		active++
+		peak = max(peak, active)
+		mutex.Unlock()
+		time.Sleep(50 * time.Millisecond)
+		mutex.Lock()
+		active--
+		posted++
+		mutex.Unlock()
+	}))
+	defer server.Close()
+	webhook := newConcurrent(&Webhook{url: server.URL, batch: 1}, 3)
+	for i := 0; i < 9; i++ {
+		entry := newEntry("https://example.com/feed", &Channel{}, &Item{Title: fmt.Sprintf("Item %d", i), GUID: strconv.Itoa(i)})
+		if err := webhook.Deliver(entry); !errors.Is(err, errInFlight) {
+			t.Fatalf("Deliver should post in the background, got %v", err)
+		}
+	}
+	if err := webhook.Flush(); err != nil {
+		t.Fatalf("Flush returned error: %v", err)
+	}
+	if posted != 9 || peak < 2 || peak > 3 {
+		t.Errorf("expected 9 posts with up to 3 at a time, got %d posts with %d at a time", posted, peak)
+	}
+}
+
+func TestConcurrentJournalsAndReportsFailedPosts(t *testing.T) {
+	originalClock := clock
+	originalLogger := logger
+	clock = &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	var logs bytes.Buffer
+	logger = log.New(&logs, "", 0)
+	defer func() {
+		clock = originalClock
+		logger = originalLogger
+	}()
+	j, err := loadJournal(filepath.Join(t.TempDir(), "journal.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	down := &recordingSink{err: errors.New("503 Service Unavailable")}
+	concurrent := newConcurrent(&Journaled{sink: down, journal: j}, 1)
+	deliverTo(concurrent, newEntry("https://example.com/rss", &Channel{}, &Item{Title: "Story", GUID: "1"}))
+	if err := concurrent.Flush(); err == nil || !strings.Contains(err.Error(), "503") {
+		t.Errorf("expected Flush to report the failed post, got %v", err)
+	}
+	if j.count() != 1 {
+		t.Errorf("expected the failed post to be journaled, got %d", j.count())
+	}
+	if strings.Contains(logs.String(), "Delivered 'Story'") {
+		t.Errorf("a failed post should not be logged as delivered: %s", logs.String())
+	}
+}
+
+func TestPostCycleRunsCommandWithBatch(t *testing.T) {
+	dir := t.TempDir()
+	output := filepath.Join(dir, "batch.json")
+	count := filepath.Join(dir, "count.txt")
+	hook := &PostCycle{command: `cat > "` + output + `"; echo "$RSSP_COUNT" > "` + count + `"`}
+	err := hook.Flush()
+	if err != nil {
+		t.Fatalf("empty Flush returned error: %v", err)
+	}
+	if _, err := os.Stat(output); err == nil {
+		t.Fatal("command should not run when nothing is new")
+	}
+	hook.Deliver(&Entry{Feed: "https://example.com/feed", Item: &Item{Title: "One"}})
+	hook.Deliver(&Entry{Feed: "https://example.com/feed", Item: &Item{Title: "Two"}})
+	err = hook.Flush()
+	if err != nil {
+		t.Fatalf("Flush returned error: %v", err)
+	}
+	content, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("command should have written its input: %v", err)
+	}
+	var batch []Record
+	if err := json.Unmarshal(content, &batch); err != nil {
+		t.Fatalf("stdin should be a JSON array: %v (%q)", err, content)
+	}
+	if len(batch) != 2 || batch[1].Title != "Two" {
+		t.Errorf("unexpected batch %+v", batch)
+	}
+	n, _ := os.ReadFile(count)
+	if strings.TrimSpace(string(n)) != "2" {
+		t.Errorf("RSSP_COUNT should be 2, got %q", n)
+	}
+}
+
+func TestPostCycleReportsFailure(t *testing.T) {
+	hook := &PostCycle{command: "exit 3"}
+	hook.Deliver(&Entry{Item: &Item{Title: "One"}})
+	if err := hook.Flush(); err == nil {
+		t.Error("expected failing command to be reported")
+	}
+}
+
+func TestClientStopsEndlessRedirects(t *testing.T) {
+	hops := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hops++
+		http.Redirect(w, r, fmt.Sprintf("/hop%d", hops), http.StatusFound)
+	}))
+	defer server.Close()
+	if text := extractBasicContent(server.URL, client); text != "" {
+		t.Errorf("expected no content from a redirect loop, got %q", text)
+	}
+	if hops != maxRedirects {
+		t.Errorf("expected %d requests, got %d", maxRedirects, hops)
+	}
+}
+
+func TestClientRefusesRedirectToFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "file:///etc/passwd", http.StatusFound)
+	}))
+	defer server.Close()
+	if _, err := client.Get(server.URL); err == nil {
+		t.Error("expected redirect to a file URL to be refused")
+	}
+}
+
+func TestGuardedClientRefusesLoopback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "internal secret")
+	}))
+	defer server.Close()
+	if _, err := guardedClient().Get(server.URL); err == nil || !strings.Contains(err.Error(), "private address") {
+		t.Errorf("expected loopback fetch to be refused, got %v", err)
+	}
+}
+
+func TestEmitItemsFetchesInParallelButWritesInOrder(t *testing.T) {
+	originalOutputFile := outputFile
+	originalMaxLength := maxLength
+	fastFetched := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			select {
+			case <-fastFetched:
+			case <-time.After(5 * time.Second):
+			}
+			fmt.Fprint(w, "<p>Slow article</p>")
+			return
+		}
+		fmt.Fprint(w, "<p>Fast article</p>")
+		close(fastFetched)
+	}))
+	defer server.Close()
+	tempFile := filepath.Join(t.TempDir(), "out.txt")
+	file, _ := os.Create(tempFile)
+	outputFile = file
+	maxLength = 2000
+	defer func() {
+		outputFile = originalOutputFile
+		maxLength = originalMaxLength
+		file.Close()
+	}()
+	start := time.Now()
+	emitItems(context.Background(), "https://example.com/feed", []Item{
+		{GUID: "1", Link: server.URL + "/slow"},
+		{GUID: "2", Link: server.URL + "/fast"},
+	}, &Channel{})
+	if time.Since(start) > 4*time.Second {
+		t.Error("expected the fast article to be fetched while the slow one was pending")
+	}
+	content, _ := os.ReadFile(tempFile)
+	if string(content) != "Slow article\n\nFast article\n\n" {
+		t.Errorf("expected items in feed order, got %q", content)
+	}
+}
+
+func TestPipeWriterReopensNamedPipe(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pipe")
+	if err := exec.Command("mkfifo", path).Run(); err != nil {
+		t.Skipf("mkfifo is not available: %v", err)
+	}
+	originalOutputFile := outputFile
+	originalLogger := logger
+	var logs strings.Builder
+	logger = log.New(&logs, "", 0)
+	outputPath = path
+	brokenPipe = "reopen"
+	defer func() {
+		outputFile = originalOutputFile
+		logger = originalLogger
+		outputPath = ""
+		brokenPipe = "exit"
+	}()
+	readLine := func(delay time.Duration) <-chan string {
+		result := make(chan string, 1)
+		go func() {
+			time.Sleep(delay)
+			reader, err := os.Open(path)
+			if err != nil {
+				result <- err.Error()
+				return
+			}
+			defer reader.Close()
+			line, _ := bufio.NewReader(reader).ReadString('\n')
+			result <- line
+		}()
+		return result
+	}
+	first := readLine(0)
+	file, err := openOutput(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	outputFile = file
+	if _, err := (pipeWriter{}).Write([]byte("first\n")); err != nil {
+		t.Fatal(err)
+	}
+	if line := <-first; line != "first\n" {
+		t.Fatalf("first reader got %q", line)
+	}
+	second := readLine(100 * time.Millisecond)
+	if _, err := (pipeWriter{}).Write([]byte("second\n")); err != nil {
+		t.Fatal(err)
+	}
+	outputFile.Close()
+	if line := <-second; line != "second\n" {
+		t.Errorf("second reader got %q", line)
+	}
+	if !strings.Contains(logs.String(), "reopening") {
+		t.Errorf("expected the pipe to be reopened, log was %q", logs.String())
+	}
+}
+
+func issueCertificate(t *testing.T, template *x509.Certificate, parent *x509.Certificate, signer *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey, []byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parent == nil {
+		parent, signer = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+	return cert, key,
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestGRPCSubscribeStreamsItemsOverMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	expiry := time.Now().Add(time.Hour)
+	ca, caKey, caPEM, _ := issueCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "rssp test CA"}, NotAfter: expiry,
+		IsCA: true, BasicConstraintsValid: true, KeyUsage: x509.KeyUsageCertSign,
+	}, nil, nil)
+	_, _, serverPEM, serverKey := issueCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2), Subject: pkix.Name{CommonName: "localhost"}, NotAfter: expiry,
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1")}, ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, ca, caKey)
+	_, _, clientPEM, clientKey := issueCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(3), Subject: pkix.Name{CommonName: "consumer"}, NotAfter: expiry,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, ca, caKey)
+	os.WriteFile(filepath.Join(dir, "ca.pem"), caPEM, 0600)
+	os.WriteFile(filepath.Join(dir, "server.pem"), serverPEM, 0600)
+	os.WriteFile(filepath.Join(dir, "server.key"), serverKey, 0600)
+
+	hub := &Hub{}
+	server, err := newGRPCServer(filepath.Join(dir, "server.pem"), filepath.Join(dir, "server.key"), filepath.Join(dir, "ca.pem"), hub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	listener, _ := net.Listen("tcp", "127.0.0.1:0")
+	go server.Serve(listener)
+	defer server.Stop()
+
+	pool := x509.NewCertPool()
+	pool.AppendCertsFromPEM(caPEM)
+	pair, _ := tls.X509KeyPair(clientPEM, clientKey)
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+		RootCAs: pool, Certificates: []tls.Certificate{pair},
+	})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	stream, err := api.NewItemsClient(conn).Subscribe(t.Context(), &api.FilterRequest{Keywords: []string{"rust"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		for {
+			hub.mutex.Lock()
+			ready := len(hub.subscribers) > 0
+			hub.mutex.Unlock()
+			if ready {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		hub.Deliver(&Entry{Feed: "https://a.com/rss", Item: &Item{Title: "Python news"}})
+		hub.Deliver(&Entry{Feed: "https://a.com/rss", Item: &Item{Title: "Rust news", GUID: "r1"}})
+	}()
+	item, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item.Title != "Rust news" || item.Guid != "r1" || item.Schema != "rssp/v1" {
+		t.Errorf("unexpected item: %v", item)
+	}
+
+	anonymous, _ := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{RootCAs: pool})))
+	defer anonymous.Close()
+	rejected, err := api.NewItemsClient(anonymous).Subscribe(t.Context(), &api.FilterRequest{})
+	if err == nil {
+		_, err = rejected.Recv()
+	}
+	if err == nil {
+		t.Error("expected a client without certificate to be rejected")
+	}
+}
+
+func TestPollFeedFollowsPermanentMove(t *testing.T) {
+	originalClock := clock
+	originalLogger := logger
+	old := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/old":
+			old++
+			http.Redirect(w, r, "/temporary", http.StatusMovedPermanently)
+		case "/temporary":
+			http.Redirect(w, r, "/new", http.StatusMovedPermanently)
+		case "/new":
+			fmt.Fprint(w, `<rss><channel><item><guid>1</guid></item></channel></rss>`)
+		}
+	}))
+	defer server.Close()
+	clock = &fakeClock{limit: 2}
+	var logs strings.Builder
+	logger = log.New(&logs, "", 0)
+	stats, _ = loadStats("")
+	defer func() {
+		clock = originalClock
+		logger = originalLogger
+		stats = nil
+	}()
+	state := &FeedState{url: server.URL + "/old", items: make(map[string]time.Time)}
+	pollFeed(context.Background(), state)
+	if old != 1 {
+		t.Errorf("expected the old location to be requested once, got %d", old)
+	}
+	if state.location != server.URL+"/new" || stats.location(state.url) != server.URL+"/new" {
+		t.Errorf("expected the new location to be remembered, got %q", state.location)
+	}
+	if !strings.Contains(logs.String(), "moved permanently") {
+		t.Errorf("expected the move to be logged, log was %q", logs.String())
+	}
+}
+
+func TestPermanentLocationIgnoresTemporaryRedirects(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/feed":
+			http.Redirect(w, r, "/moved", http.StatusPermanentRedirect)
+		case "/moved":
+			http.Redirect(w, r, "/today", http.StatusFound)
+		default:
+			fmt.Fprint(w, "<rss/>")
+		}
+	}))
+	defer server.Close()
+	resp, err := client.Get(server.URL + "/feed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if location := permanentLocation(resp); location != server.URL+"/moved" {
+		t.Errorf("expected only the permanent hop to count, got %q", location)
+	}
+}
+
+func TestFetchFeedReportsChallengeAndRetriesThroughProxy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/proxy" {
+			if r.URL.Query().Get("url") == "" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(w, `<rss><channel><title>Unblocked</title></channel></rss>`)
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `<html><head><title>Just a moment...</title></head><body>challenge-platform</body></html>`)
+	}))
+	defer server.Close()
+	_, err := fetchFeed(context.Background(), server.URL+"/feed")
+	var blocked *ChallengeError
+	if !errors.As(err, &blocked) || blocked.Vendor != "Cloudflare" {
+		t.Fatalf("expected a Cloudflare challenge error, got %v", err)
+	}
+	scrapingProxy = server.URL + "/proxy?url="
+	defer func() { scrapingProxy = "" }()
+	feed, err := fetchFeed(context.Background(), server.URL+"/feed")
+	if err != nil || feed.Channel.Title != "Unblocked" {
+		t.Errorf("expected the feed to be fetched through the proxy, got %v", err)
+	}
+}
+
+func TestCookieJarPreloadsAndKeepsCookies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := r.Cookie("session"); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if _, err := r.Cookie("consent"); err != nil {
+			http.SetCookie(w, &http.Cookie{Name: "consent", Value: "yes", Path: "/"})
+			fmt.Fprint(w, "<p>Consent banner</p>")
+			return
+		}
+		fmt.Fprint(w, "<p>Full article</p>")
+	}))
+	defer server.Close()
+	host := strings.Split(strings.TrimPrefix(server.URL, "http://"), ":")[0]
+	path := filepath.Join(t.TempDir(), "cookies.txt")
+	os.WriteFile(path, []byte("# Netscape HTTP Cookie File\n"+host+"\tFALSE\t/\tFALSE\t0\tsession\tabc\n"), 0600)
+	jar, err := newJar(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	originalMaxLength := maxLength
+	maxLength = 2000
+	defer func() { maxLength = originalMaxLength }()
+	httpClient := &http.Client{Jar: jar}
+	if text := extractBasicContent(server.URL+"/post", httpClient); text != "Consent banner" {
+		t.Errorf("expected the preloaded cookie to be sent, got %q", text)
+	}
+	if text := extractBasicContent(server.URL+"/post", httpClient); text != "Full article" {
+		t.Errorf("expected the cookie set by the site to be kept, got %q", text)
+	}
+}
+
+func TestCookiesFileRejectsMalformedLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cookies.txt")
+	os.WriteFile(path, []byte("example.com TRUE / FALSE 0 name value\n"), 0600)
+	if _, err := newJar(path); err == nil || !strings.Contains(err.Error(), ":1:") {
+		t.Errorf("expected an error pointing at line 1, got %v", err)
+	}
+}
+
+func TestTrustedFeedSkipsRelevanceFilter(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"NOT_RELEVANT"}}]}`))
+	}))
+	defer server.Close()
+	originalURL := openaiURL
+	originalFocus := focus
+	originalOutputFile := outputFile
+	openaiURL = server.URL
+	focus = "databases"
+	tempFile := filepath.Join(t.TempDir(), "out.txt")
+	file, _ := os.Create(tempFile)
+	outputFile = file
+	os.Setenv("OPENAI_API_KEY", "test-key")
+	defer func() {
+		openaiURL = originalURL
+		focus = originalFocus
+		outputFile = originalOutputFile
+		file.Close()
+		os.Unsetenv("OPENAI_API_KEY")
+	}()
+	printItem("https://noisy.com/feed", &Item{GUID: "n1", Description: "Celebrity gossip"}, &Channel{})
+	printItem("https://trusted.com/feed", &Item{GUID: "t1", Description: "Weekly team update", Trusted: true}, &Channel{})
+	content, _ := os.ReadFile(tempFile)
+	if string(content) != "Weekly team update\n\n" {
+		t.Errorf("expected only the trusted item, got %q", content)
+	}
+	if calls != 1 {
+		t.Errorf("expected the LLM to be asked about the noisy item only, got %d calls", calls)
+	}
+}
+
+func TestEvaluateComparesTwoPrompts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		answer := "RELEVANT: ok"
+		if strings.Contains(string(body), "Strict") && !strings.Contains(string(body), "Postgres") {
+			answer = "NOT_RELEVANT"
+		}
+		fmt.Fprintf(w, `{"choices":[{"message":{"role":"assistant","content":%q}}]}`, answer)
+	}))
+	defer server.Close()
+	originalURL := openaiURL
+	openaiURL = server.URL
+	os.Setenv("OPENAI_API_KEY", "test-key")
+	defer func() {
+		openaiURL = originalURL
+		os.Unsetenv("OPENAI_API_KEY")
+	}()
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "db.json"), []byte(`{"content":"Postgres 18 released","relevant":true}`), 0600)
+	os.WriteFile(filepath.Join(dir, "sports.json"), []byte(`{"content":"Football results","relevant":false}`), 0600)
+	os.WriteFile(filepath.Join(dir, "a.txt"), []byte(`Strict: is '{{.Content}}' about {{.Topic}}?`), 0600)
+	os.WriteFile(filepath.Join(dir, "b.txt"), []byte(`Loose: is '{{.Content}}' about {{.Topic}}?`), 0600)
+	var out bytes.Buffer
+	code := evaluate([]string{
+		"--fixtures", dir, "--focus", "databases",
+		"--prompt-a", filepath.Join(dir, "a.txt"), "--prompt-b", filepath.Join(dir, "b.txt"),
+	}, &out)
+	if code != 0 {
+		t.Fatalf("eval failed with %d", code)
+	}
+	for _, expected := range []string{
+		"sports   not relevant  not relevant  relevant",
+		"Prompt A: 2/2 correct, 0 false positives, 0 false negatives",
+		"Prompt B: 1/2 correct, 1 false positives, 0 false negatives",
+		"A and B agree on 1/2 fixtures",
+	} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("expected %q in report:\n%s", expected, out.String())
+		}
+	}
+}
+
+func TestAuditLogRecordsRelevanceDecision(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"NOT_RELEVANT"}}],"usage":{"total_tokens":42}}`))
+	}))
+	defer server.Close()
+	originalURL := openaiURL
+	openaiURL = server.URL
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	auditFile, _ = os.Create(path)
+	os.Setenv("OPENAI_API_KEY", "test-key")
+	defer func() {
+		openaiURL = originalURL
+		auditFile.Close()
+		auditFile = nil
+		os.Unsetenv("OPENAI_API_KEY")
+	}()
+	if _, relevant := processWithOpenAI(context.Background(), "Audited gardening tips", "kernels", "", "guid-7"); relevant {
+		t.Fatal("expected the item to be filtered out")
+	}
+	data, _ := os.ReadFile(path)
+	var call Call
+	if err := json.Unmarshal(data, &call); err != nil {
+		t.Fatalf("audit log is not JSON: %q", data)
+	}
+	if call.Item != "guid-7" || call.Purpose != "relevance" || call.Decision != "not_relevant" ||
+		call.Model != "gpt-3.5-turbo" || call.Tokens != 42 || len(call.Prompt) != 64 {
+		t.Errorf("unexpected audit record: %+v", call)
+	}
+}
+
+func TestReprocessEmitsNewlyAcceptedItems(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		answer := "NOT_RELEVANT"
+		if strings.Contains(string(body), "Postgres") {
+			answer = "RELEVANT: Postgres got faster"
+		}
+		fmt.Fprintf(w, `{"choices":[{"message":{"role":"assistant","content":%q}}]}`, answer)
+	}))
+	defer server.Close()
+	originalURL := openaiURL
+	openaiURL = server.URL
+	os.Setenv("OPENAI_API_KEY", "test-key")
+	defer func() {
+		openaiURL = originalURL
+		os.Unsetenv("OPENAI_API_KEY")
+	}()
+	dir := t.TempDir()
+	rejected := filepath.Join(dir, "rejected.jsonl")
+	os.WriteFile(rejected, []byte(
+		`{"schema":"rssp/v1","feed":"f","title":"Postgres 18","description":"Postgres 18 released","guid":"a","rejected":"not relevant to 'databases'"}`+"\n"+
+			`{"schema":"rssp/v1","feed":"f","title":"Football","description":"Football results","guid":"b","rejected":"not relevant to 'databases'"}`+"\n"), 0600)
+	prompt := filepath.Join(dir, "prompt.txt")
+	os.WriteFile(prompt, []byte(`Is '{{.Content}}' about {{.Topic}}?`), 0600)
+	var out bytes.Buffer
+	if code := reprocess([]string{"--rejected", rejected, "--prompt", prompt}, &out); code != 0 {
+		t.Fatalf("reprocess failed with %d", code)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected one rescued item, got %q", out.String())
+	}
+	var record Record
+	if err := json.Unmarshal([]byte(lines[0]), &record); err != nil {
+		t.Fatalf("output is not JSON: %q", lines[0])
+	}
+	if record.GUID != "a" || record.Summary != "Postgres got faster" || record.Rejected != "" {
+		t.Errorf("unexpected rescued record: %+v", record)
+	}
+}
+
+func TestSpeechWritesSpokenSummaries(t *testing.T) {
+	var input string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request SpeechRequest
+		json.NewDecoder(r.Body).Decode(&request)
+		input = request.Input
+		w.Write([]byte("ID3 fake audio"))
+	}))
+	defer server.Close()
+	originalURL := openaiURL
+	openaiURL = server.URL
+	defer func() { openaiURL = originalURL }()
+	dir := t.TempDir()
+	speech := &Speech{dir: dir, voice: "alloy", token: "test-key"}
+	entry := newEntry("", &Channel{}, &Item{Title: "Postgres 18 released", PubDate: "Mon, 02 Jan 2006 15:04:05 MST"})
+	entry.Summary = "It is faster."
+	if err := speech.Deliver(entry); err != nil {
+		t.Fatalf("failed to deliver: %v", err)
+	}
+	if input != "Postgres 18 released. It is faster." {
+		t.Errorf("unexpected text sent to TTS: %q", input)
+	}
+	audio, err := os.ReadFile(filepath.Join(dir, "2006-01-02 Postgres 18 released.mp3"))
+	if err != nil || string(audio) != "ID3 fake audio" {
+		t.Errorf("unexpected audio file: %q, %v", audio, err)
+	}
+}
+
+func TestSpeechUsesLocalEngineAndPlayer(t *testing.T) {
+	played := filepath.Join(t.TempDir(), "played.txt")
+	speech := &Speech{engine: "tr a-z A-Z", player: `cat > "` + played + `"`}
+	if err := speech.Deliver(&Entry{Item: &Item{Title: "Hello"}, Summary: "world"}); err != nil {
+		t.Fatalf("failed to deliver: %v", err)
+	}
+	if err := speech.Flush(); err != nil {
+		t.Fatalf("failed to flush: %v", err)
+	}
+	if audio, _ := os.ReadFile(played); string(audio) != "HELLO. WORLD" {
+		t.Errorf("unexpected audio played: %q", audio)
+	}
+}
+
+func TestFetchFeedAbortsWhenDeadlinePasses(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+	originalClient := client
+	client = &http.Client{}
+	defer func() { client = originalClient }()
+	ctx, cancel := deadline(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	started := time.Now()
+	_, err := fetchFeed(ctx, server.URL+"/feed")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the deadline to abort the request, got %v", err)
+	}
+	if elapsed := time.Since(started); elapsed > 5*time.Second {
+		t.Errorf("request was not aborted, took %s", elapsed)
+	}
+}
+
+func TestExtractBasicContentSendsAcceptLanguage(t *testing.T) {
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get("Accept-Language")
+		w.Write([]byte("<html><body><p>Guten Tag</p></body></html>"))
+	}))
+	defer server.Close()
+	acceptLanguage = "de-DE,de;q=0.9"
+	defer func() { acceptLanguage = "" }()
+	extractBasicContent(server.URL, &http.Client{})
+	if received != "de-DE,de;q=0.9" {
+		t.Errorf("expected Accept-Language to be sent, got %q", received)
+	}
+}
+
+func TestTrendingEndpointCountsRecentTerms(t *testing.T) {
+	originalClock := clock
+	fake := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	clock = fake
+	defer func() { clock = originalClock }()
+	trending := newTrending(time.Hour)
+	trending.Deliver(&Entry{Item: &Item{Title: "Postgres outage", Link: "https://a.com/1"}})
+	fake.now = fake.now.Add(2 * time.Hour)
+	trending.Deliver(&Entry{Item: &Item{Title: "Kubernetes release", Link: "https://a.com/2"}})
+	trending.Deliver(&Entry{Item: &Item{Title: "Kubernetes bug", Link: "https://b.com/3"}, Entities: &Entities{Companies: []string{"Google"}}})
+	server := httptest.NewServer(newServeMux(newAggregate("rssp", 10, 100), trending, newMetrics(false)))
+	defer server.Close()
+	resp, err := http.Get(server.URL + "/trending?limit=2")
+	if err != nil {
+		t.Fatalf("failed to query trending: %v", err)
+	}
+	defer resp.Body.Close()
+	var report TrendingReport
+	json.NewDecoder(resp.Body).Decode(&report)
+	if report.Window != "1h0m0s" || len(report.Terms) != 2 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	first := report.Terms[0]
+	if first.Term != "Kubernetes" || first.Count != 2 || strings.Join(first.Links, " ") != "https://b.com/3 https://a.com/2" {
+		t.Errorf("unexpected top term: %+v", first)
+	}
+	if report.Terms[1].Term != "Google" {
+		t.Errorf("expected expired terms to be dropped: %+v", report.Terms)
+	}
+}
+
+func TestServeRequiresTokenOrBasicAuth(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	})
+	server := httptest.NewServer(protect(ok, "s3cret", "admin", "pa55"))
+	defer server.Close()
+	for name, setup := range map[string]func(*http.Request){
+		"nothing":     func(r *http.Request) {},
+		"wrong token": func(r *http.Request) { r.Header.Set("Authorization", "Bearer nope") },
+		"wrong user":  func(r *http.Request) { r.SetBasicAuth("admin", "nope") },
+		"token":       func(r *http.Request) { r.Header.Set("Authorization", "Bearer s3cret") },
+		"basic auth":  func(r *http.Request) { r.SetBasicAuth("admin", "pa55") },
+	} {
+		req, _ := http.NewRequest("GET", server.URL, nil)
+		setup(req)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("%s: request failed: %v", name, err)
+		}
+		resp.Body.Close()
+		expected := http.StatusUnauthorized
+		if name == "token" || name == "basic auth" {
+			expected = http.StatusOK
+		}
+		if resp.StatusCode != expected {
+			t.Errorf("%s: expected %d, got %d", name, expected, resp.StatusCode)
+		}
+	}
+}
+
+func TestServeExposesItemsAsJSONAndHealth(t *testing.T) {
+	aggregate := newAggregate("rssp", 10, 100)
+	aggregate.Deliver(&Entry{Feed: "https://a.example.com/rss", Item: &Item{Title: "Security update", Link: "https://a.example.com/1", GUID: "1"}})
+	aggregate.Deliver(&Entry{Feed: "https://b.example.com/rss", Item: &Item{Title: "Football", Link: "https://b.example.com/2", GUID: "2"}})
+	server := httptest.NewServer(protect(newServeMux(aggregate, newTrending(time.Hour), newMetrics(false)), "s3cret", "", ""))
+	defer server.Close()
+	resp, err := http.Get(server.URL + "/healthz")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected /healthz to need no token, got %d", resp.StatusCode)
+	}
+	for path, expected := range map[string][]string{
+		"/items":                                {"Football", "Security update"},
+		"/items?feed=https://a.example.com/rss": {"Security update"},
+		"/items?tags=cricket":                   {},
+		"/items?page=2":                         {},
+	} {
+		req, _ := http.NewRequest("GET", server.URL+path, nil)
+		req.Header.Set("Authorization", "Bearer s3cret")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		var records []Record
+		err = json.NewDecoder(resp.Body).Decode(&records)
+		resp.Body.Close()
+		if err != nil || records == nil {
+			t.Fatalf("%s: expected a JSON array: %v", path, err)
+		}
+		var titles []string
+		for _, record := range records {
+			titles = append(titles, record.Title)
+		}
+		if strings.Join(titles, ",") != strings.Join(expected, ",") {
+			t.Errorf("%s: expected %v, got %v", path, expected, titles)
+		}
+	}
+	req, _ := http.NewRequest("GET", server.URL+"/feed.xml", nil)
+	req.Header.Set("Authorization", "Bearer s3cret")
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if feed, err := parseFeed(body); err != nil || len(feed.Channel.Items) != 2 {
+		t.Errorf("expected /feed.xml to serve the merged feed, got %q: %v", body, err)
+	}
+}
+
+func TestServeLogsAccessAndCountsPerEndpoint(t *testing.T) {
+	var logs bytes.Buffer
+	originalLogger := logger
+	logger = log.New(&logs, "", 0)
+	defer func() { logger = originalLogger }()
+	metrics := newMetrics(true)
+	server := httptest.NewServer(metrics.observe(newServeMux(newAggregate("rssp", 10, 100), newTrending(time.Hour), metrics)))
+	defer server.Close()
+	for _, path := range []string{"/trending", "/trending", "/missing"} {
+		req, _ := http.NewRequest("GET", server.URL+path, nil)
+		req.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.1")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		resp.Body.Close()
+	}
+	if !strings.Contains(logs.String(), `access client=10.0.0.1 method=GET path="/trending" status=200`) {
+		t.Errorf("unexpected access log:\n%s", logs.String())
+	}
+	resp, err := http.Get(server.URL + "/metrics")
+	if err != nil {
+		t.Fatalf("failed to query metrics: %v", err)
+	}
+	defer resp.Body.Close()
+	var report MetricsReport
+	json.NewDecoder(resp.Body).Decode(&report)
+	endpoints := report.Endpoints
+	if endpoints["GET /trending"].Requests != 2 || endpoints["GET /trending"].Bytes == 0 || endpoints["other"].Requests != 1 {
+		t.Errorf("unexpected metrics: %+v", report)
+	}
+	if report.Downloaded.Feeds == nil {
+		t.Errorf("expected downloaded bytes in metrics: %+v", report)
+	}
+}
+
+func TestServedFeedHonorsConditionalRequestsAndGzip(t *testing.T) {
+	aggregate := newAggregate("rssp", 10, 100)
+	aggregate.Deliver(&Entry{Feed: "https://example.com/rss", Item: &Item{Title: "Hello", Link: "https://example.com/1", GUID: "1"}, Summary: "World"})
+	server := httptest.NewServer(aggregate)
+	defer server.Close()
+	raw := &http.Client{Transport: &http.Transport{DisableCompression: true}}
+	resp, err := raw.Get(server.URL + "/feed")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	etag, modified := resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")
+	if resp.StatusCode != http.StatusOK || etag == "" || modified == "" {
+		t.Fatalf("expected validators, got %d %q %q", resp.StatusCode, etag, modified)
+	}
+	feed, err := parseFeed(body)
+	if err != nil || len(feed.Channel.Items) != 1 || feed.Channel.Items[0].Description != "World" {
+		t.Fatalf("unexpected feed %q: %v", body, err)
+	}
+	for header, value := range map[string]string{"If-None-Match": etag, "If-Modified-Since": modified} {
+		req, _ := http.NewRequest("GET", server.URL+"/feed", nil)
+		req.Header.Set(header, value)
+		resp, err := raw.Do(req)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusNotModified {
+			t.Errorf("expected 304 for %s, got %d", header, resp.StatusCode)
+		}
+	}
+	req, _ := http.NewRequest("GET", server.URL+"/feed", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	resp, err = raw.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	reader, err := gzip.NewReader(resp.Body)
+	if err != nil || resp.Header.Get("Content-Encoding") != "gzip" {
+		t.Fatalf("expected a gzipped response: %v", err)
+	}
+	unzipped, _ := io.ReadAll(reader)
+	if !bytes.Equal(unzipped, body) {
+		t.Errorf("gzipped feed differs from the plain one")
+	}
+}
+
+func TestServedFeedLinksArchivedPages(t *testing.T) {
+	aggregate := newAggregate("rssp", 2, 5)
+	for i := 1; i <= 6; i++ {
+		aggregate.Deliver(&Entry{Item: &Item{Title: fmt.Sprintf("Item %d", i), GUID: strconv.Itoa(i)}})
+	}
+	server := httptest.NewServer(aggregate)
+	defer server.Close()
+	get := func(path string) (int, string) {
+		resp, err := http.Get(server.URL + path)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(body)
+	}
+	_, first := get("/feed")
+	if !strings.Contains(first, `<atom:link rel="next" href="`+server.URL+`/feed?page=2"></atom:link>`) || strings.Contains(first, `rel="previous"`) {
+		t.Errorf("unexpected links on the first page:\n%s", first)
+	}
+	_, last := get("/feed?page=3")
+	if !strings.Contains(last, "<title>Item 2</title>") || strings.Contains(last, "Item 1<") || strings.Contains(last, `rel="next"`) {
+		t.Errorf("unexpected last page:\n%s", last)
+	}
+	if !strings.Contains(last, `<atom:link rel="previous" href="`+server.URL+`/feed?page=2"></atom:link>`) {
+		t.Errorf("expected a link to the previous page:\n%s", last)
+	}
+	if status, _ := get("/feed?page=4"); status != http.StatusNotFound {
+		t.Errorf("expected 404 beyond the last page, got %d", status)
+	}
+}
+
+func TestServedFeedFiltersPerRequest(t *testing.T) {
+	aggregate := newAggregate("rssp", 10, 100)
+	aggregate.Deliver(&Entry{Feed: "https://a.com/rss", Channel: Channel{Language: "en"}, Item: &Item{Title: "Security patch for OpenSSL", GUID: "1"}})
+	aggregate.Deliver(&Entry{Feed: "https://b.com/rss", Channel: Channel{Language: "de"}, Item: &Item{Title: "Sicherheitsupdate", GUID: "2"}, Entities: &Entities{Products: []string{"Security"}}})
+	aggregate.Deliver(&Entry{Feed: "https://a.com/rss", Channel: Channel{Language: "en"}, Item: &Item{Title: "Football results", GUID: "3"}})
+	server := httptest.NewServer(aggregate)
+	defer server.Close()
+	titles := func(query string) (int, []string) {
+		resp, err := http.Get(server.URL + "/feed?" + query)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode != http.StatusOK {
+			return resp.StatusCode, nil
+		}
+		feed, _ := parseFeed(body)
+		var found []string
+		for _, item := range feed.Channel.Items {
+			found = append(found, item.GUID[strings.Index(item.GUID, ":")+1:])
+		}
+		return resp.StatusCode, found
+	}
+	for query, expected := range map[string]string{
+		"tags=security":              "2 1",
+		"tags=security&lang=en":      "1",
+		"feed=https://a.com/rss":     "3 1",
+		"tags=football,openssl":      "3 1",
+		"lang=de&feed=https://a.com": "",
+	} {
+		if _, found := titles(query); strings.Join(found, " ") != expected {
+			t.Errorf("%s: expected %q, got %q", query, expected, found)
+		}
+	}
+	if status, _ := titles("min_score=50"); status != http.StatusBadRequest {
+		t.Errorf("expected min_score to be rejected, got %d", status)
+	}
+}
+
+func TestServedFeedAttributesItemsToTheirSources(t *testing.T) {
+	aggregate := newAggregate("rssp", 10, 100)
+	aggregate.Deliver(&Entry{Feed: "https://a.com/rss", Channel: Channel{Title: "A & Co"}, Item: &Item{Title: "One", GUID: "1"}})
+	aggregate.Deliver(&Entry{Feed: "https://b.com/rss", Item: &Item{Title: "Two", GUID: "2"}})
+	server := httptest.NewServer(aggregate)
+	defer server.Close()
+	resp, err := http.Get(server.URL + "/feed")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	for _, expected := range []string{
+		`<source url="https://a.com/rss">A &amp; Co</source>`,
+		`<source url="https://b.com/rss">b.com</source>`,
+	} {
+		if !strings.Contains(string(body), expected) {
+			t.Errorf("expected %s in:\n%s", expected, body)
+		}
+	}
+}
+
+func TestRunPollsFeedsInProcess(t *testing.T) {
+	originalClock := clock
+	originalLogger := logger
+	originalSinks := sinks
+	originalOutputFile := outputFile
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		if polls == 1 {
+			fmt.Fprint(w, `<rss><channel><item><guid>1</guid><title>Old</title></item></channel></rss>`)
+			return
+		}
+		fmt.Fprint(w, `<rss><channel><item><guid>2</guid><title>New</title></item><item><guid>1</guid><title>Old</title></item></channel></rss>`)
+	}))
+	defer server.Close()
+	clock = &fakeClock{limit: 2}
+	logger = log.New(io.Discard, "", 0)
+	sinks = nil
+	out, _ := os.Create(filepath.Join(t.TempDir(), "out.txt"))
+	defer func() {
+		clock = originalClock
+		logger = originalLogger
+		sinks = originalSinks
+		outputFile = originalOutputFile
+		out.Close()
+	}()
+	recorder := &recordingSink{}
+	err := Run(context.Background(), Config{Feeds: []string{server.URL + "#trust=trusted"}, Output: out}, recorder)
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	if len(recorder.entries) != 1 || recorder.entries[0].Item.Title != "New" || !recorder.entries[0].Item.Trusted {
+		t.Errorf("expected only the new item to be delivered, got %d entries", len(recorder.entries))
+	}
+	if len(sinks) != 0 || outputFile != originalOutputFile {
+		t.Errorf("expected Run to restore the globals, got %d sinks left behind", len(sinks))
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	clock = &fakeClock{limit: 100}
+	if err := Run(ctx, Config{Feeds: []string{server.URL}, Output: out}); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected a canceled run to stop, got %v", err)
+	}
+	if err := Run(context.Background(), Config{Feeds: []string{server.URL + "#schedule=someday"}}); !errors.Is(err, ErrConfig) {
+		t.Errorf("expected an invalid schedule to be rejected as a configuration error, got %v", err)
+	}
+	if err := Run(context.Background(), Config{}); !errors.Is(err, ErrConfig) {
+		t.Errorf("expected a run without feeds to be rejected as a configuration error, got %v", err)
+	}
+}
+
+func scriptedFeed(t *testing.T, steps ...string) *httptest.Server {
+	var mutex sync.Mutex
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		step := steps[min(calls, len(steps)-1)]
+		calls++
+		mutex.Unlock()
+		if step == "" {
+			http.Error(w, "scripted failure", http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprintf(w, `<rss><channel><title>Scripted</title>%s</channel></rss>`, step)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func runScripted(t *testing.T, cfg Config, polls int) string {
+	originalClock := clock
+	originalLogger := logger
+	originalSinks := sinks
+	originalOutputFile := outputFile
+	originalFormat := outputFormat
+	originalFull := fullOutput
+	originalFocus := focus
+	originalOrder := itemOrder
+	t.Cleanup(func() {
+		clock = originalClock
+		logger = originalLogger
+		sinks = originalSinks
+		outputFile = originalOutputFile
+		outputFormat = originalFormat
+		fullOutput = originalFull
+		focus = originalFocus
+		itemOrder = originalOrder
+	})
+	clock = &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), limit: polls}
+	logger = log.New(io.Discard, "", 0)
+	sinks = nil
+	path := filepath.Join(t.TempDir(), "out.txt")
+	out, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("failed to create output: %v", err)
+	}
+	defer out.Close()
+	cfg.Output = out
+	if err := Run(context.Background(), cfg); err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	content, _ := os.ReadFile(path)
+	return string(content)
+}
+
+func TestScriptedFeedSurvivesErrorsAndEmitsOnlyNewItems(t *testing.T) {
+	item := func(id string, date string) string {
+		return fmt.Sprintf(`<item><guid>%s</guid><title>Item %s</title><description>News %s</description><pubDate>%s</pubDate></item>`, id, id, id, date)
+	}
+	server := scriptedFeed(t,
+		item("1", "Mon, 01 Jan 2024 10:00:00 GMT"),
+		item("2", "Tue, 02 Jan 2024 10:00:00 GMT")+item("1", "Mon, 01 Jan 2024 10:00:00 GMT"),
+		"",
+		item("3", "Wed, 03 Jan 2024 10:00:00 GMT")+item("2", "Tue, 02 Jan 2024 10:00:00 GMT"),
+	)
+	output := runScripted(t, Config{Feeds: []string{server.URL}}, 4)
+	if expected := "02-01-2024 News 2\n\n03-01-2024 News 3\n\n"; output != expected {
+		t.Errorf("expected %q, got %q", expected, output)
+	}
+	late := scriptedFeed(t, "", item("1", ""), item("2", "")+item("1", ""))
+	output = runScripted(t, Config{Feeds: []string{late.URL}, Format: "jsonl"}, 3)
+	if strings.Count(output, "\n") != 1 || !strings.Contains(output, `"title":"Item 2"`) {
+		t.Errorf("expected one JSON record after the feed recovers, got %q", output)
+	}
+}
+
+func TestSpikeAlertsDoNotInterleaveWithItems(t *testing.T) {
+	originalOutputFile, originalClock, originalSinks, originalLogger := outputFile, clock, sinks, logger
+	path := filepath.Join(t.TempDir(), "out.txt")
+	file, _ := os.Create(path)
+	outputFile = file
+	outputBuffer = bufio.NewWriterSize(file, 64)
+	clock = &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	sinks = nil
+	logger = nil
+	defer func() {
+		outputFile, clock, sinks, logger = originalOutputFile, originalClock, originalSinks, originalLogger
+		outputBuffer = nil
+		unsynced = 0
+		file.Close()
+	}()
+	spikes := newSpikes(time.Hour, 1)
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			writeEntry(newEntry("https://example.com/rss", &Channel{}, &Item{Title: fmt.Sprintf("Story %d", i)}))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			spikes.Deliver(&Entry{Feed: "a", Item: &Item{Title: fmt.Sprintf("Term%d", i)}})
+		}
+	}()
+	wg.Wait()
+	outputMutex.Lock()
+	syncOutput()
+	outputMutex.Unlock()
+	data, _ := os.ReadFile(path)
+	blocks := strings.Split(strings.TrimSuffix(string(data), "\n\n"), "\n\n")
+	alerts := 0
+	for _, block := range blocks {
+		if strings.HasPrefix(block, "ALERT: ") {
+			alerts++
+			if strings.Contains(block, "Story") {
+				t.Errorf("alert interleaved with an item: %q", block)
+			}
+		}
+	}
+	if alerts != 50 {
+		t.Errorf("expected 50 intact alerts, got %d", alerts)
+	}
+}
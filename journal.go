@@ -0,0 +1,186 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+type Pending struct {
+	Sink     string    `json:"sink"`
+	Entry    *Entry    `json:"entry"`
+	Attempts int       `json:"attempts"`
+	Next     time.Time `json:"next"`
+	Error    string    `json:"error,omitempty"`
+}
+
+type Journal struct {
+	path    string
+	mutex   sync.Mutex
+	Pending []*Pending `json:"pending"`
+}
+
+type Journaled struct {
+	sink    Sink
+	journal *Journal
+	mutex   sync.Mutex
+}
+
+var journal *Journal
+
+func loadJournal(path string) (*Journal, error) {
+	j := &Journal{path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return j, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, j); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return j, nil
+}
+
+func (j *Journal) add(sink string, entry *Entry, err error) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	j.Pending = append(j.Pending, &Pending{
+		Sink:     sink,
+		Entry:    entry,
+		Attempts: 1,
+		Next:     clock.Now().Add(backoff(1)),
+		Error:    err.Error(),
+	})
+}
+
+func (j *Journal) due(sink string) []*Pending {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	var due []*Pending
+	now := clock.Now()
+	for _, p := range j.Pending {
+		if p.Sink == sink && !p.Next.After(now) {
+			due = append(due, p)
+		}
+	}
+	return due
+}
+
+func (j *Journal) settle(p *Pending, err error) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	if err != nil {
+		p.Attempts++
+		p.Next = clock.Now().Add(backoff(p.Attempts))
+		p.Error = err.Error()
+		return
+	}
+	for i, pending := range j.Pending {
+		if pending == p {
+			j.Pending = append(j.Pending[:i], j.Pending[i+1:]...)
+			break
+		}
+	}
+}
+
+func (j *Journal) count() int {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	return len(j.Pending)
+}
+
+func (j *Journal) save() error {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	data, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return err
+	}
+	temp, err := os.CreateTemp(filepath.Dir(j.path), ".rssp-journal-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name())
+	if _, err := temp.Write(data); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(temp.Name(), j.path)
+}
+
+func backoff(attempts int) time.Duration {
+	if attempts > 7 {
+		return time.Hour
+	}
+	return min(time.Minute<<(attempts-1), time.Hour)
+}
+
+func (j *Journaled) Name() string {
+	return j.sink.Name()
+}
+
+func (j *Journaled) Key() string {
+	return j.sink.Key()
+}
+
+func (j *Journaled) Deliver(entry *Entry) error {
+	j.retry()
+	err := j.sink.Deliver(entry)
+	if err == nil {
+		return nil
+	}
+	heartbeat.undelivered.Add(1)
+	j.journal.add(j.sink.Name(), entry, err)
+	if err := j.journal.save(); err != nil && logger != nil {
+		logger.Printf("Failed to save the delivery journal: %v", err)
+	}
+	return fmt.Errorf("%w, will retry in %s", err, backoff(1))
+}
+
+func (j *Journaled) Flush() error {
+	j.retry()
+	if flusher, ok := j.sink.(Flusher); ok {
+		return flusher.Flush()
+	}
+	return nil
+}
+
+func (j *Journaled) retry() {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	due := j.journal.due(j.sink.Name())
+	if len(due) == 0 {
+		return
+	}
+	delivered := 0
+	for _, p := range due {
+		err := j.sink.Deliver(p.Entry)
+		j.journal.settle(p, err)
+		if err != nil {
+			heartbeat.undelivered.Add(1)
+			if logger != nil {
+				logger.Printf("Retry %d of '%s' to %s failed: %v", p.Attempts-1, p.Entry.Item.Title, j.sink.Name(), err)
+			}
+			continue
+		}
+		delivered++
+	}
+	if logger != nil {
+		logger.Printf("Delivered %d of %d journaled items to %s", delivered, len(due), j.sink.Name())
+	}
+	if err := j.journal.save(); err != nil && logger != nil {
+		logger.Printf("Failed to save the delivery journal: %v", err)
+	}
+}
@@ -0,0 +1,208 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type JSONFeed struct {
+	Version     string         `json:"version"`
+	Title       string         `json:"title"`
+	HomePageURL string         `json:"home_page_url,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Icon        string         `json:"icon,omitempty"`
+	Language    string         `json:"language,omitempty"`
+	Items       []JSONFeedItem `json:"items"`
+	path        string
+	limit       int
+	dirty       bool
+	mutex       sync.Mutex
+}
+
+type JSONFeedItem struct {
+	ID            string       `json:"id"`
+	URL           string       `json:"url,omitempty"`
+	ExternalURL   string       `json:"external_url,omitempty"`
+	Title         string       `json:"title,omitempty"`
+	ContentHTML   string       `json:"content_html,omitempty"`
+	ContentText   string       `json:"content_text"`
+	Summary       string       `json:"summary,omitempty"`
+	Image         string       `json:"image,omitempty"`
+	DatePublished string       `json:"date_published,omitempty"`
+	DateModified  string       `json:"date_modified,omitempty"`
+	Tags          []string     `json:"tags,omitempty"`
+	Language      string       `json:"language,omitempty"`
+	Authors       []Author     `json:"authors,omitempty"`
+	Attachments   []Attachment `json:"attachments,omitempty"`
+	Source        *Source      `json:"_source,omitempty"`
+}
+
+type Attachment struct {
+	URL      string `json:"url"`
+	MimeType string `json:"mime_type"`
+	Size     int64  `json:"size_in_bytes,omitempty"`
+}
+
+type Source struct {
+	Title       string `json:"title,omitempty"`
+	HomePageURL string `json:"home_page_url,omitempty"`
+	FeedURL     string `json:"feed_url"`
+}
+
+type Author struct {
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+}
+
+const jsonFeedVersion = "https://jsonfeed.org/version/1.1"
+
+func newJSONFeed(path string, title string, limit int) (*JSONFeed, error) {
+	f := &JSONFeed{Version: jsonFeedVersion, Title: title, path: path, limit: limit}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, f); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	f.Version = jsonFeedVersion
+	f.Title = title
+	return f, nil
+}
+
+func parseJSONFeed(data []byte) (*RSS, error) {
+	var feed JSONFeed
+	if err := json.Unmarshal(data, &feed); err != nil {
+		return nil, fail(ErrParse, fmt.Errorf("JSON Feed parsing failed: %w", err))
+	}
+	if !strings.HasPrefix(feed.Version, "https://jsonfeed.org/version/") {
+		return nil, fail(ErrParse, fmt.Errorf("not a JSON Feed, its version is %q", feed.Version))
+	}
+	rss := &RSS{Channel: Channel{
+		Title:       feed.Title,
+		Link:        feed.HomePageURL,
+		Description: feed.Description,
+		Language:    feed.Language,
+		Image:       Image{URL: feed.Icon},
+	}}
+	for _, entry := range feed.Items {
+		item := Item{
+			Title:       entry.Title,
+			Link:        entry.URL,
+			Description: entry.ContentHTML,
+			PubDate:     entry.DatePublished,
+			GUID:        entry.ID,
+			Lang:        entry.Language,
+		}
+		if item.Link == "" {
+			item.Link = entry.ExternalURL
+		}
+		for _, text := range []string{entry.ContentText, entry.Summary} {
+			if item.Description == "" {
+				item.Description = text
+			}
+		}
+		if item.PubDate == "" {
+			item.PubDate = entry.DateModified
+		}
+		if entry.Image != "" {
+			item.Thumbnails = []MediaThumbnail{{URL: entry.Image}}
+		}
+		for _, attachment := range entry.Attachments {
+			enclosure := Enclosure{URL: attachment.URL, Type: attachment.MimeType}
+			if attachment.Size > 0 {
+				enclosure.Length = strconv.FormatInt(attachment.Size, 10)
+			}
+			item.Enclosures = append(item.Enclosures, enclosure)
+		}
+		rss.Channel.Items = append(rss.Channel.Items, item)
+	}
+	if logger != nil {
+		logger.Printf("Successfully parsed JSON Feed with %d items", len(rss.Channel.Items))
+	}
+	return rss, nil
+}
+
+func (f *JSONFeed) Name() string {
+	return "JSON Feed"
+}
+
+func (f *JSONFeed) Key() string {
+	return "jsonfeed"
+}
+
+func (f *JSONFeed) Deliver(entry *Entry) error {
+	item := JSONFeedItem{
+		ID:          entry.guid(),
+		URL:         entry.Item.Link,
+		Title:       strip(entry.Item.Title),
+		ContentText: entry.text(),
+		Summary:     entry.Summary,
+		Image:       entry.Item.thumbnail(),
+		Tags:        entry.Tags(),
+		Language:    language(entry.Item, &entry.Channel),
+		Authors:     []Author{{Name: entry.source(), URL: entry.Channel.Link}},
+		Source:      &Source{Title: entry.source(), HomePageURL: entry.Channel.Link, FeedURL: entry.Feed},
+	}
+	if entry.Content != "" {
+		item.ContentText = entry.Content
+	}
+	if !entry.Published.IsZero() {
+		item.DatePublished = entry.Published.Format(time.RFC3339)
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	items := []JSONFeedItem{item}
+	for _, existing := range f.Items {
+		if existing.ID != item.ID {
+			items = append(items, existing)
+		}
+	}
+	if len(items) > f.limit {
+		items = items[:f.limit]
+	}
+	f.Items = items
+	f.dirty = true
+	return nil
+}
+
+func (f *JSONFeed) Flush() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if !f.dirty {
+		return nil
+	}
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	temp, err := os.CreateTemp(filepath.Dir(f.path), ".rssp-feed-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name())
+	if _, err := temp.Write(data); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(temp.Name(), f.path); err != nil {
+		return err
+	}
+	f.dirty = false
+	return nil
+}
@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var (
+	compactLayout []string
+	layoutFields  = []string{"date", "channel", "summary", "title", "link"}
+)
+
+func parseCompactLayout(text string) ([]string, error) {
+	var layout []string
+	for _, field := range strings.Split(text, ",") {
+		field = strings.ToLower(strings.TrimSpace(field))
+		if !slices.Contains(layoutFields, field) {
+			return nil, fmt.Errorf("unknown field %q in compact layout, expected some of %s", field, strings.Join(layoutFields, ","))
+		}
+		if slices.Contains(layout, field) {
+			return nil, fmt.Errorf("field %q repeats in compact layout", field)
+		}
+		layout = append(layout, field)
+	}
+	return layout, nil
+}
+
+func compactLine(entry *Entry) string {
+	layout := compactLayout
+	if layout == nil {
+		layout = []string{"date", "summary"}
+		if authored {
+			layout = append(layout, "channel")
+		}
+	}
+	var parts []string
+	for _, field := range layout {
+		var part string
+		switch field {
+		case "date":
+			part = entry.date()
+		case "channel":
+			if entry.Channel.Title != "" {
+				name := entry.Channel.Title
+				if strings.Count(name, " ") > 2 {
+					name = hostname(entry.Feed)
+				}
+				part = "[" + name + "]"
+			}
+		case "summary":
+			switch {
+			case entry.Summary != "":
+				part = entry.Summary
+			case entry.Description != "":
+				part = entry.Description
+			default:
+				part = entry.Content
+			}
+		case "title":
+			part = strip(entry.Item.Title)
+		case "link":
+			part = entry.Item.Link
+		}
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,113 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+var (
+	linkTemplate *template.Template
+	attributeRe  = regexp.MustCompile(`(?i)\b(href|src)(\s*=\s*)(["'])([^"']*)["']`)
+)
+
+func newLinkTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("link").Funcs(template.FuncMap{"param": param}).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse link template: %w", err)
+	}
+	return tmpl, nil
+}
+
+func param(link string, name string, value string) string {
+	u, err := url.Parse(link)
+	if err != nil {
+		return link
+	}
+	if u.RawQuery != "" {
+		u.RawQuery += "&"
+	}
+	u.RawQuery += url.QueryEscape(name) + "=" + url.QueryEscape(value)
+	return u.String()
+}
+
+func rewriteLink(item *Item, feedURL string, channel *Channel) *Item {
+	if linkTemplate == nil || item.Link == "" {
+		return item
+	}
+	var link bytes.Buffer
+	err := linkTemplate.Execute(&link, struct {
+		Link    string
+		Host    string
+		Feed    string
+		Channel string
+	}{
+		Link:    item.Link,
+		Host:    hostname(item.Link),
+		Feed:    feedURL,
+		Channel: channel.Title,
+	})
+	if err != nil {
+		if logger != nil {
+			logger.Printf("Failed to rewrite link %s: %v", item.Link, err)
+		}
+		return item
+	}
+	rewritten := *item
+	rewritten.Link = strings.TrimSpace(link.String())
+	if rewritten.GUID == "" {
+		rewritten.GUID = item.Link
+	}
+	return &rewritten
+}
+
+func resolveLinks(rss *RSS, feedURL string) {
+	base, err := url.Parse(feedURL)
+	if err != nil {
+		return
+	}
+	channel := &rss.Channel
+	channel.Link = absolute(base, channel.Link)
+	if site, err := url.Parse(strings.TrimSpace(channel.Link)); err == nil && site.IsAbs() {
+		base = site
+	}
+	channel.Image.URL = absolute(base, channel.Image.URL)
+	channel.ITunesImage.Href = absolute(base, channel.ITunesImage.Href)
+	for i := range channel.Items {
+		item := &channel.Items[i]
+		item.Link = absolute(base, item.Link)
+		item.Comments = absolute(base, item.Comments)
+		item.CommentRSS = absolute(base, item.CommentRSS)
+		item.ITunesImage.Href = absolute(base, item.ITunesImage.Href)
+		for j := range item.Enclosures {
+			item.Enclosures[j].URL = absolute(base, item.Enclosures[j].URL)
+		}
+		for _, thumbnails := range [][]MediaThumbnail{item.Thumbnails, item.MediaGroup.Thumbnails} {
+			for j := range thumbnails {
+				thumbnails[j].URL = absolute(base, thumbnails[j].URL)
+			}
+		}
+		item.Description = attributeRe.ReplaceAllStringFunc(item.Description, func(attribute string) string {
+			match := attributeRe.FindStringSubmatch(attribute)
+			return match[1] + match[2] + match[3] + absolute(base, match[4]) + match[3]
+		})
+	}
+}
+
+func absolute(base *url.URL, link string) string {
+	trimmed := strings.TrimSpace(link)
+	if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+		return link
+	}
+	ref, err := url.Parse(trimmed)
+	if err != nil || ref.IsAbs() {
+		return link
+	}
+	return base.ResolveReference(ref).String()
+}
@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var translations = map[string]map[string]string{
+	"de": {
+		"Title":                           "Titel",
+		"Link":                            "Link",
+		"Article":                         "Artikel",
+		"Archived":                        "Archiviert",
+		"Content":                         "Inhalt",
+		"Description":                     "Beschreibung",
+		"Published":                       "Veröffentlicht",
+		"first seen, no date in the feed": "zuerst gesehen, kein Datum im Feed",
+		"People":                          "Personen",
+		"Companies":                       "Unternehmen",
+		"Products":                        "Produkte",
+		"Read the original":               "Zum Original",
+	},
+	"es": {
+		"Title":                           "Título",
+		"Link":                            "Enlace",
+		"Article":                         "Artículo",
+		"Archived":                        "Archivado",
+		"Content":                         "Contenido",
+		"Description":                     "Descripción",
+		"Published":                       "Publicado",
+		"first seen, no date in the feed": "visto por primera vez, sin fecha en el feed",
+		"People":                          "Personas",
+		"Companies":                       "Empresas",
+		"Products":                        "Productos",
+		"Read the original":               "Leer el original",
+	},
+	"fr": {
+		"Title":                           "Titre",
+		"Link":                            "Lien",
+		"Article":                         "Article",
+		"Archived":                        "Archivé",
+		"Content":                         "Contenu",
+		"Description":                     "Description",
+		"Published":                       "Publié",
+		"first seen, no date in the feed": "vu pour la première fois, pas de date dans le flux",
+		"People":                          "Personnes",
+		"Companies":                       "Entreprises",
+		"Products":                        "Produits",
+		"Read the original":               "Lire l'original",
+	},
+	"ru": {
+		"Title":                           "Заголовок",
+		"Link":                            "Ссылка",
+		"Article":                         "Статья",
+		"Archived":                        "Архив",
+		"Content":                         "Содержание",
+		"Description":                     "Описание",
+		"Published":                       "Опубликовано",
+		"first seen, no date in the feed": "впервые замечено, в ленте нет даты",
+		"People":                          "Люди",
+		"Companies":                       "Компании",
+		"Products":                        "Продукты",
+		"Read the original":               "Читать оригинал",
+	},
+}
+
+var locale = "en"
+
+func parseLocale(text string) (string, error) {
+	lang, _, _ := strings.Cut(strings.ToLower(strings.ReplaceAll(text, "_", "-")), "-")
+	if _, ok := translations[lang]; ok || lang == "en" {
+		return lang, nil
+	}
+	known := []string{"en"}
+	for name := range translations {
+		known = append(known, name)
+	}
+	sort.Strings(known)
+	return "", fmt.Errorf("unknown locale %q, expected one of %s", text, strings.Join(known, ", "))
+}
+
+func translate(text string) string {
+	if translated, ok := translations[locale][text]; ok {
+		return translated
+	}
+	return text
+}
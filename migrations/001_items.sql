@@ -0,0 +1,21 @@
+-- SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+-- SPDX-License-Identifier: MIT
+
+CREATE TABLE items (
+  feed TEXT NOT NULL,
+  guid TEXT NOT NULL,
+  channel TEXT NOT NULL DEFAULT '',
+  title TEXT NOT NULL DEFAULT '',
+  link TEXT NOT NULL DEFAULT '',
+  description TEXT NOT NULL DEFAULT '',
+  content TEXT NOT NULL DEFAULT '',
+  summary TEXT NOT NULL DEFAULT '',
+  published TEXT NOT NULL DEFAULT '',
+  entities JSONB,
+  archived TEXT NOT NULL DEFAULT '',
+  collected TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (feed, guid)
+);
+
+CREATE INDEX items_collected ON items (collected);
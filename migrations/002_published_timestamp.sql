@@ -0,0 +1,17 @@
+-- SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+-- SPDX-License-Identifier: MIT
+
+CREATE FUNCTION pg_temp.rssp_timestamp(value TEXT) RETURNS TIMESTAMPTZ AS $$
+BEGIN
+  RETURN NULLIF(value, '')::TIMESTAMPTZ;
+EXCEPTION WHEN others THEN
+  RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE items
+  ALTER COLUMN published DROP DEFAULT,
+  ALTER COLUMN published DROP NOT NULL,
+  ALTER COLUMN published TYPE TIMESTAMPTZ USING pg_temp.rssp_timestamp(published);
+
+CREATE INDEX items_published ON items (published);
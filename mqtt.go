@@ -0,0 +1,190 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+type MQTT struct {
+	broker   string
+	topic    *template.Template
+	username string
+	password string
+	conn     net.Conn
+	mutex    sync.Mutex
+}
+
+func newMQTT(broker string, topic string) (*MQTT, error) {
+	u, err := url.Parse(broker)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid MQTT broker URL: %s", broker)
+	}
+	tmpl, err := template.New("topic").Parse(topic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse MQTT topic template: %w", err)
+	}
+	return &MQTT{
+		broker:   broker,
+		topic:    tmpl,
+		username: os.Getenv("MQTT_USERNAME"),
+		password: os.Getenv("MQTT_PASSWORD"),
+	}, nil
+}
+
+func (m *MQTT) Name() string {
+	return "MQTT"
+}
+
+func (m *MQTT) Key() string {
+	return "mqtt"
+}
+
+func (m *MQTT) Deliver(entry *Entry) error {
+	payload, err := json.Marshal(entry.Record())
+	if err != nil {
+		return err
+	}
+	var topic bytes.Buffer
+	err = m.topic.Execute(&topic, struct {
+		Host     string
+		Feed     string
+		Channel  string
+		Language string
+	}{
+		Host:     hostname(entry.Feed),
+		Feed:     entry.Feed,
+		Channel:  entry.Channel.Title,
+		Language: language(entry.Item, &entry.Channel),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to render MQTT topic: %w", err)
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for attempt := 0; ; attempt++ {
+		if m.conn == nil {
+			err = m.connect()
+			if err != nil {
+				return err
+			}
+		}
+		_, err = m.conn.Write(publishPacket(topic.String(), payload))
+		if err == nil || attempt > 0 {
+			break
+		}
+		m.conn.Close()
+		m.conn = nil
+	}
+	return err
+}
+
+func (m *MQTT) connect() error {
+	u, _ := url.Parse(m.broker)
+	var conn net.Conn
+	var err error
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	switch u.Scheme {
+	case "ssl", "tls", "mqtts":
+		host := u.Host
+		if u.Port() == "" {
+			host += ":8883"
+		}
+		conn, err = tls.DialWithDialer(dialer, "tcp", host, &tls.Config{ServerName: u.Hostname()})
+	default:
+		host := u.Host
+		if u.Port() == "" {
+			host += ":1883"
+		}
+		conn, err = dialer.Dial("tcp", host)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect to MQTT broker: %w", err)
+	}
+	clientID := fmt.Sprintf("rssp-%d", os.Getpid())
+	_, err = conn.Write(connectPacket(clientID, m.username, m.password))
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to send MQTT CONNECT: %w", err)
+	}
+	conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+	ack := make([]byte, 4)
+	_, err = io.ReadFull(conn, ack)
+	conn.SetReadDeadline(time.Time{})
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to read MQTT CONNACK: %w", err)
+	}
+	if ack[0] != 0x20 || ack[3] != 0 {
+		conn.Close()
+		return fmt.Errorf("MQTT broker refused connection with code %d", ack[3])
+	}
+	if logger != nil {
+		logger.Printf("Connected to MQTT broker %s", u.Host)
+	}
+	m.conn = conn
+	return nil
+}
+
+func connectPacket(clientID string, username string, password string) []byte {
+	var body bytes.Buffer
+	body.Write(mqttString("MQTT"))
+	body.WriteByte(4)
+	flags := byte(0x02)
+	if username != "" {
+		flags |= 0x80
+		if password != "" {
+			flags |= 0x40
+		}
+	}
+	body.WriteByte(flags)
+	body.Write([]byte{0, 0})
+	body.Write(mqttString(clientID))
+	if username != "" {
+		body.Write(mqttString(username))
+		if password != "" {
+			body.Write(mqttString(password))
+		}
+	}
+	return mqttPacket(0x10, body.Bytes())
+}
+
+func publishPacket(topic string, payload []byte) []byte {
+	var body bytes.Buffer
+	body.Write(mqttString(strings.TrimSpace(topic)))
+	body.Write(payload)
+	return mqttPacket(0x30, body.Bytes())
+}
+
+func mqttPacket(header byte, body []byte) []byte {
+	packet := []byte{header}
+	length := len(body)
+	for {
+		digit := byte(length % 128)
+		length /= 128
+		if length > 0 {
+			digit |= 0x80
+		}
+		packet = append(packet, digit)
+		if length == 0 {
+			break
+		}
+	}
+	return append(packet, body...)
+}
+
+func mqttString(text string) []byte {
+	return append([]byte{byte(len(text) >> 8), byte(len(text))}, text...)
+}
@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+//go:embed note.md
+var embeddedNote string
+
+var unsafeName = regexp.MustCompile(`[\\/:*?"<>|#^\[\]\x00-\x1f]+`)
+
+type Notes struct {
+	dir  string
+	tmpl *template.Template
+}
+
+type Note struct {
+	Title              string
+	Date               string
+	Source             string
+	Feed               string
+	Link               string
+	Image              string
+	Tags               []string
+	Summary            string
+	ChannelLink        string
+	ChannelDescription string
+	ChannelImage       string
+	Language           string
+	Extra              map[string]string
+}
+
+func newNotes(dir string, templateFile string) (*Notes, error) {
+	text := embeddedNote
+	if templateFile != "" {
+		data, err := os.ReadFile(templateFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read note template: %w", err)
+		}
+		text = string(data)
+	}
+	tmpl, err := template.New("note").Funcs(template.FuncMap{"yaml": yamlString, "label": translate}).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse note template: %w", err)
+	}
+	err = os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create notes directory: %w", err)
+	}
+	return &Notes{dir: dir, tmpl: tmpl}, nil
+}
+
+func (n *Notes) Name() string {
+	return "notes"
+}
+
+func (n *Notes) Key() string {
+	return "notes"
+}
+
+func (n *Notes) Deliver(entry *Entry) error {
+	published := entry.Published
+	if published.IsZero() {
+		published = clock.Now()
+	}
+	note := Note{
+		Title:              strip(entry.Item.Title),
+		Date:               published.Format("2006-01-02"),
+		Source:             entry.source(),
+		Feed:               entry.Feed,
+		Link:               entry.Item.Link,
+		Image:              entry.Item.thumbnail(),
+		Tags:               entry.Tags(),
+		Summary:            entry.text(),
+		ChannelLink:        entry.Channel.Link,
+		ChannelDescription: strip(entry.Channel.Description),
+		ChannelImage:       entry.Channel.artwork(),
+		Language:           language(entry.Item, &entry.Channel),
+		Extra:              entry.Item.Extra,
+	}
+	var buf bytes.Buffer
+	err := n.tmpl.Execute(&buf, note)
+	if err != nil {
+		return fmt.Errorf("failed to render note: %w", err)
+	}
+	name := note.Date + " " + slug(note.Title)
+	path := filepath.Join(n.dir, name+".md")
+	for i := 2; ; i++ {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			break
+		}
+		path = filepath.Join(n.dir, fmt.Sprintf("%s %d.md", name, i))
+	}
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
+
+func slug(title string) string {
+	name := strings.Join(strings.Fields(unsafeName.ReplaceAllString(title, " ")), " ")
+	if runes := []rune(name); len(runes) > 100 {
+		name = strings.TrimSpace(string(runes[:100]))
+	}
+	if name == "" {
+		name = "untitled"
+	}
+	return name
+}
+
+func yamlString(text string) string {
+	quoted, _ := json.Marshal(text)
+	return string(quoted)
+}
@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+type Desktop struct{}
+
+const toastScript = `[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] > $null
+$template = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent([Windows.UI.Notifications.ToastTemplateType]::ToastText02)
+$text = $template.GetElementsByTagName('text')
+$text.Item(0).AppendChild($template.CreateTextNode($env:RSSP_TITLE)) > $null
+$text.Item(1).AppendChild($template.CreateTextNode($env:RSSP_BODY)) > $null
+[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier('rssp').Show([Windows.UI.Notifications.ToastNotification]::new($template))`
+
+func (d *Desktop) Name() string {
+	return "desktop notification"
+}
+
+func (d *Desktop) Key() string {
+	return "notify"
+}
+
+func (d *Desktop) Deliver(entry *Entry) error {
+	title := strip(entry.Item.Title)
+	if title == "" {
+		title = entry.source()
+	}
+	body := truncate(entry.text(), 200)
+	cmd, err := notification(runtime.GOOS, title, body)
+	if err != nil {
+		return err
+	}
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s failed: %v: %s", cmd.Args[0], err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func notification(goos string, title string, body string) (*exec.Cmd, error) {
+	switch goos {
+	case "darwin":
+		return exec.Command("osascript",
+			"-e", "on run argv",
+			"-e", "display notification (item 2 of argv) with title (item 1 of argv)",
+			"-e", "end run",
+			title, body,
+		), nil
+	case "windows":
+		cmd := exec.Command("powershell", "-NoProfile", "-NonInteractive", "-Command", toastScript)
+		cmd.Env = append(os.Environ(), "RSSP_TITLE="+title, "RSSP_BODY="+body)
+		return cmd, nil
+	case "linux", "freebsd", "openbsd", "netbsd":
+		return exec.Command("notify-send", "--app-name=rssp", "--", title, body), nil
+	default:
+		return nil, fmt.Errorf("desktop notifications are not supported on %s", goos)
+	}
+}
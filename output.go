@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+var (
+	outputBuffer  *bufio.Writer
+	syncEvery     int
+	unsynced      int
+	flushInterval = time.Second
+	outputPath    string
+	brokenPipe    = "exit"
+	rejectedFile  *os.File
+	rejectedMutex sync.Mutex
+)
+
+type pipeWriter struct{}
+
+func (pipeWriter) Write(p []byte) (int, error) {
+	n, err := outputFile.Write(p)
+	for errors.Is(err, syscall.EPIPE) && brokenPipe == "reopen" && outputPath != "" {
+		if logger != nil {
+			logger.Printf("Reader of %s went away, reopening it", outputPath)
+		}
+		outputFile.Close()
+		outputFile, err = openOutput(outputPath)
+		if err != nil {
+			return n, err
+		}
+		var m int
+		m, err = outputFile.Write(p[n:])
+		n += m
+	}
+	return n, err
+}
+
+func openOutput(path string) (*os.File, error) {
+	return os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+}
+
+func outputFailed(err error) {
+	if err == nil {
+		return
+	}
+	if errors.Is(err, syscall.EPIPE) {
+		if logger != nil {
+			logger.Printf("Reader of the output went away, exiting")
+		}
+		os.Exit(exitFailure)
+	}
+	if logger != nil {
+		logger.Printf("Failed to write output: %v", err)
+	}
+}
+
+func writeOutput(text string) {
+	if outputBuffer == nil {
+		_, err := pipeWriter{}.Write([]byte(text))
+		outputFailed(err)
+		syncOutput()
+		return
+	}
+	_, err := outputBuffer.WriteString(text)
+	outputFailed(err)
+	unsynced++
+	if syncEvery > 0 && unsynced >= syncEvery {
+		syncOutput()
+	}
+}
+
+func syncOutput() {
+	if outputBuffer != nil {
+		outputFailed(outputBuffer.Flush())
+	}
+	unsynced = 0
+	if info, err := outputFile.Stat(); err == nil && info.Mode().IsRegular() {
+		outputFile.Sync()
+		if logger != nil {
+			logger.Printf("Output flushed and synced")
+		}
+	}
+}
+
+func flushPeriodically() {
+	for clock.Sleep(flushInterval) {
+		outputMutex.Lock()
+		if unsynced > 0 {
+			syncOutput()
+		}
+		outputMutex.Unlock()
+	}
+}
+
+func flushOnShutdown() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	<-signals
+	stop()
+	if !drain(drainTimeout) && logger != nil {
+		logger.Printf("Gave up on flushing sinks after %s", drainTimeout)
+	}
+	outputMutex.Lock()
+	syncOutput()
+	os.Exit(exitOK)
+}
+
+func reject(entry *Entry) {
+	if rejectedFile == nil {
+		return
+	}
+	line, err := json.Marshal(entry.Record())
+	if err != nil {
+		return
+	}
+	rejectedMutex.Lock()
+	defer rejectedMutex.Unlock()
+	if _, err := rejectedFile.Write(append(line, '\n')); err != nil && logger != nil {
+		logger.Printf("Failed to write rejected item: %v", err)
+	}
+}
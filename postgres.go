@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"database/sql"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+
+	_ "github.com/lib/pq"
+)
+
+//go:embed migrations/*.sql
+var embeddedMigrations embed.FS
+
+type Postgres struct {
+	db *sql.DB
+}
+
+type Migration struct {
+	Version int
+	Name    string
+	SQL     string
+}
+
+const upsertItem = `INSERT INTO items (feed, guid, channel, title, link, description, content, summary, published, entities, archived)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+ON CONFLICT (feed, guid) DO UPDATE SET
+  channel = EXCLUDED.channel,
+  title = EXCLUDED.title,
+  link = EXCLUDED.link,
+  description = EXCLUDED.description,
+  content = EXCLUDED.content,
+  summary = EXCLUDED.summary,
+  published = EXCLUDED.published,
+  entities = EXCLUDED.entities,
+  archived = EXCLUDED.archived,
+  updated = now()`
+
+func newPostgres(dsn string) (*Postgres, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open PostgreSQL connection: %w", err)
+	}
+	err = db.Ping()
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to connect to PostgreSQL: %w", err)
+	}
+	err = migrate(db)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &Postgres{db: db}, nil
+}
+
+func (p *Postgres) Name() string {
+	return "PostgreSQL"
+}
+
+func (p *Postgres) Key() string {
+	return "postgres"
+}
+
+func (p *Postgres) Deliver(entry *Entry) error {
+	record := entry.Record()
+	var entities any
+	if record.Entities != nil {
+		data, err := json.Marshal(record.Entities)
+		if err != nil {
+			return err
+		}
+		entities = string(data)
+	}
+	var published any
+	if !entry.Published.IsZero() {
+		published = entry.Published.UTC()
+	}
+	_, err := p.db.Exec(upsertItem,
+		record.Feed, rowKey(record), record.Channel, record.Title, record.Link,
+		record.Description, record.Content, record.Summary, published,
+		entities, record.Archived,
+	)
+	return err
+}
+
+func rowKey(record Record) string {
+	if record.GUID != "" {
+		return record.GUID
+	}
+	return "sha256:" + summaryKey(record.Title+"\n"+record.Description+"\n"+record.Content)
+}
+
+func migrations() ([]Migration, error) {
+	files, err := fs.Glob(embeddedMigrations, "migrations/*.sql")
+	if err != nil {
+		return nil, err
+	}
+	var result []Migration
+	for _, file := range files {
+		name := strings.TrimPrefix(file, "migrations/")
+		version, err := strconv.Atoi(strings.SplitN(name, "_", 2)[0])
+		if err != nil {
+			return nil, fmt.Errorf("migration %s has no numeric version", name)
+		}
+		data, err := embeddedMigrations.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, Migration{Version: version, Name: name, SQL: string(data)})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Version < result[j].Version })
+	return result, nil
+}
+
+func migrate(db *sql.DB) error {
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS rssp_migrations (version INTEGER PRIMARY KEY, applied TIMESTAMPTZ NOT NULL DEFAULT now())`)
+	if err != nil {
+		return fmt.Errorf("failed to create migrations table: %w", err)
+	}
+	list, err := migrations()
+	if err != nil {
+		return err
+	}
+	for _, migration := range list {
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(`LOCK TABLE rssp_migrations IN EXCLUSIVE MODE`)
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to lock migrations table: %w", err)
+		}
+		var applied bool
+		err = tx.QueryRow(`SELECT EXISTS (SELECT 1 FROM rssp_migrations WHERE version = $1)`, migration.Version).Scan(&applied)
+		if err == nil && !applied {
+			_, err = tx.Exec(migration.SQL)
+			if err == nil {
+				_, err = tx.Exec(`INSERT INTO rssp_migrations (version) VALUES ($1)`, migration.Version)
+			}
+			if err == nil && logger != nil {
+				logger.Printf("Applied PostgreSQL migration %s", migration.Name)
+			}
+		}
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %s failed: %w", migration.Name, err)
+		}
+		err = tx.Commit()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+type QuietHours struct {
+	from     time.Duration
+	to       time.Duration
+	location *time.Location
+}
+
+type Quiet struct {
+	sink  Sink
+	hours *QuietHours
+	queue []*Entry
+	mutex sync.Mutex
+}
+
+func parseQuietHours(text string) (*QuietHours, error) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 || len(fields) > 2 {
+		return nil, fmt.Errorf("invalid quiet hours %q, expected e.g. '23:00-07:00 Europe/Berlin'", text)
+	}
+	span := strings.Split(fields[0], "-")
+	if len(span) != 2 {
+		return nil, fmt.Errorf("invalid quiet hours %q, expected e.g. '23:00-07:00 Europe/Berlin'", text)
+	}
+	hours := &QuietHours{location: time.Local}
+	for i, value := range span {
+		at, err := time.Parse("15:04", value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time %q in quiet hours", value)
+		}
+		offset := time.Duration(at.Hour())*time.Hour + time.Duration(at.Minute())*time.Minute
+		if i == 0 {
+			hours.from = offset
+		} else {
+			hours.to = offset
+		}
+	}
+	if hours.from == hours.to {
+		return nil, fmt.Errorf("quiet hours %q are empty", text)
+	}
+	if len(fields) == 2 {
+		location, err := time.LoadLocation(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("unknown time zone %q in quiet hours", fields[1])
+		}
+		hours.location = location
+	}
+	return hours, nil
+}
+
+func (q *QuietHours) contains(t time.Time) bool {
+	local := t.In(q.location)
+	offset := time.Duration(local.Hour())*time.Hour + time.Duration(local.Minute())*time.Minute
+	if q.from < q.to {
+		return offset >= q.from && offset < q.to
+	}
+	return offset >= q.from || offset < q.to
+}
+
+func (q *Quiet) Name() string {
+	return q.sink.Name()
+}
+
+func (q *Quiet) Key() string {
+	return q.sink.Key()
+}
+
+func (q *Quiet) Deliver(entry *Entry) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	if q.hours.contains(clock.Now()) {
+		q.queue = append(q.queue, entry)
+		if logger != nil {
+			logger.Printf("Holding '%s' back from %s until quiet hours end", entry.Item.Title, q.sink.Name())
+		}
+		return nil
+	}
+	q.release()
+	return q.sink.Deliver(entry)
+}
+
+func (q *Quiet) Flush() error {
+	q.mutex.Lock()
+	if !q.hours.contains(clock.Now()) {
+		q.release()
+	}
+	q.mutex.Unlock()
+	if flusher, ok := q.sink.(Flusher); ok {
+		return flusher.Flush()
+	}
+	return nil
+}
+
+func (q *Quiet) release() {
+	if len(q.queue) > 0 && logger != nil {
+		logger.Printf("Quiet hours are over, delivering %d held items to %s", len(q.queue), q.sink.Name())
+	}
+	for _, entry := range q.queue {
+		if err := q.sink.Deliver(entry); err != nil && logger != nil {
+			logger.Printf("Failed to deliver '%s' to %s: %v", entry.Item.Title, q.sink.Name(), err)
+		}
+	}
+	q.queue = nil
+}
@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+)
+
+var rejectedTopic = regexp.MustCompile(`^not relevant to '(.*)'$`)
+
+func reprocess(args []string, out io.Writer) int {
+	flags := flag.NewFlagSet("reprocess", flag.ContinueOnError)
+	path := flags.String("rejected", "", "JSONL file written by rssp --rejected-output")
+	promptPath := flags.String("prompt", "", "Prompt template to use instead of the built-in one, in the format of prompt.txt")
+	topic := flags.String("focus", "", "Topic to filter by, instead of the one each item was rejected for")
+	if err := flags.Parse(args); err != nil {
+		return exitConfig
+	}
+	if *path == "" {
+		fmt.Fprintf(os.Stderr, "Error: --rejected is required\n")
+		return exitConfig
+	}
+	token := os.Getenv("OPENAI_API_KEY")
+	if token == "" {
+		fmt.Fprintf(os.Stderr, "Error: OPENAI_API_KEY is not set\n")
+		return exitConfig
+	}
+	text := embeddedPrompt
+	if *promptPath != "" {
+		data, err := os.ReadFile(*promptPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return exitFailure
+		}
+		text = string(data)
+	}
+	file, err := os.Open(*path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	total, accepted := 0, 0
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var record Record
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to parse line %d of %s: %v\n", total+1, *path, err)
+			return exitFailure
+		}
+		total++
+		subject := *topic
+		if subject == "" {
+			if match := rejectedTopic.FindStringSubmatch(record.Rejected); match != nil {
+				subject = match[1]
+			}
+		}
+		if subject == "" {
+			fmt.Fprintf(os.Stderr, "Error: '%s' has no topic and --focus is not set\n", record.Title)
+			return exitConfig
+		}
+		content := record.Description
+		if record.Content != "" {
+			content = join(content, "\n\n", record.Content)
+		}
+		if content == "" {
+			continue
+		}
+		prompt, err := render(text, struct {
+			Topic    string
+			Content  string
+			Language string
+		}{subject, content, record.Language})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return exitFailure
+		}
+		response, err := complete(context.Background(), token, prompt, nil)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: '%s': %v\n", record.Title, err)
+			return exitFailure
+		}
+		if !strings.HasPrefix(response, "RELEVANT:") {
+			continue
+		}
+		record.Summary = normalize(sanitize(strings.TrimSpace(strings.TrimPrefix(response, "RELEVANT:"))))
+		record.Rejected = ""
+		encoded, err := json.Marshal(record)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return exitFailure
+		}
+		fmt.Fprintln(out, string(encoded))
+		accepted++
+	}
+	if err := scanner.Err(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+	fmt.Fprintf(os.Stderr, "%d of %d rejected items are now accepted\n", accepted, total)
+	return exitOK
+}
@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+type Config struct {
+	Feeds    []string
+	Output   *os.File
+	Format   string
+	Full     bool
+	Focus    string
+	Order    string
+	Follow   bool
+	Trusted  bool
+	Interval time.Duration
+}
+
+func Run(ctx context.Context, cfg Config, extra ...Sink) error {
+	if len(cfg.Feeds) == 0 {
+		return fail(ErrConfig, fmt.Errorf("no feeds to poll"))
+	}
+	if cfg.Format == "" {
+		cfg.Format = "text"
+	}
+	if cfg.Format != "text" && cfg.Format != "jsonl" {
+		return fail(ErrConfig, fmt.Errorf("invalid format: %s", cfg.Format))
+	}
+	if cfg.Order == "" {
+		cfg.Order = "oldest"
+	}
+	if cfg.Order != "oldest" && cfg.Order != "newest" && cfg.Order != "document" {
+		return fail(ErrConfig, fmt.Errorf("invalid order: %s", cfg.Order))
+	}
+	states, err := feedStates(cfg)
+	if err != nil {
+		return fail(ErrConfig, err)
+	}
+	originalOutput, originalFormat, originalFull := outputFile, outputFormat, fullOutput
+	originalFocus, originalOrder, originalSinks, originalLogger := focus, itemOrder, sinks, logger
+	defer func() {
+		outputFile, outputFormat, fullOutput = originalOutput, originalFormat, originalFull
+		focus, itemOrder, sinks, logger = originalFocus, originalOrder, originalSinks, originalLogger
+	}()
+	outputFile = cfg.Output
+	if outputFile == nil {
+		outputFile = os.Stdout
+	}
+	outputFormat = cfg.Format
+	fullOutput = cfg.Full
+	focus = cfg.Focus
+	itemOrder = cfg.Order
+	sinks = append(sinks[:len(sinks):len(sinks)], extra...)
+	if logger == nil {
+		logger = log.New(io.Discard, "", 0)
+	}
+	for i, state := range states {
+		logger.Printf("Feed %d: %s", i+1, state.url)
+	}
+
+	var wg sync.WaitGroup
+	for _, state := range states {
+		wg.Add(1)
+		go func(fs *FeedState) {
+			defer wg.Done()
+			pollFeed(ctx, fs)
+		}(state)
+	}
+	wg.Wait()
+	return ctx.Err()
+}
+
+func feedStates(cfg Config) ([]*FeedState, error) {
+	states := make([]*FeedState, len(cfg.Feeds))
+	for i, spec := range cfg.Feeds {
+		uri, options := parseFeedSpec(spec)
+		states[i] = &FeedState{
+			url:      uri,
+			location: stats.location(uri),
+			items:    make(map[string]time.Time),
+			follow:   cfg.Follow,
+			trusted:  cfg.Trusted,
+			interval: cfg.Interval,
+		}
+		if seen := stats.seen(uri); seen != nil {
+			states[i].items = seen
+			states[i].resumed = true
+		}
+		switch options.Get("follow") {
+		case "external":
+			states[i].follow = true
+		case "none":
+			states[i].follow = false
+		}
+		switch options.Get("trust") {
+		case "trusted":
+			states[i].trusted = true
+		case "noisy":
+			states[i].trusted = false
+		}
+		if value := options.Get("interval"); value != "" {
+			interval, err := time.ParseDuration(value)
+			if err != nil || interval <= 0 {
+				return nil, fmt.Errorf("%s: invalid interval %q, expected e.g. 5m or 1h", uri, value)
+			}
+			states[i].interval = interval
+		}
+		if value := options.Get("schedule"); value != "" {
+			schedule, err := parseSchedule(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", uri, err)
+			}
+			states[i].schedule = schedule
+		}
+	}
+	return states, nil
+}
+
+func sleep(ctx context.Context, d time.Duration) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if sleeper, ok := clock.(ContextSleeper); ok {
+		return sleeper.SleepContext(ctx, d)
+	}
+	return clock.Sleep(d) && ctx.Err() == nil
+}
@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Schedule struct {
+	days     [7]bool
+	hours    [24]bool
+	location *time.Location
+}
+
+var weekdays = map[string]int{"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6}
+
+func parseSchedule(text string) (*Schedule, error) {
+	s := &Schedule{location: time.Local}
+	days, hours := false, false
+	for _, field := range strings.Fields(text) {
+		switch {
+		case field[0] >= '0' && field[0] <= '9':
+			if err := spans(field, 0, 23, nil, s.hours[:]); err != nil {
+				return nil, fmt.Errorf("invalid hours %q in schedule: %w", field, err)
+			}
+			hours = true
+		case spans(field, 0, 6, weekdays, s.days[:]) == nil:
+			days = true
+		default:
+			location, err := time.LoadLocation(field)
+			if err != nil {
+				return nil, fmt.Errorf("invalid schedule %q, expected e.g. 'mon-fri 9-17 Europe/Berlin'", text)
+			}
+			s.location = location
+		}
+	}
+	if !days {
+		for i := range s.days {
+			s.days[i] = true
+		}
+	}
+	if !hours {
+		for i := range s.hours {
+			s.hours[i] = true
+		}
+	}
+	return s, nil
+}
+
+func spans(field string, low int, high int, names map[string]int, set []bool) error {
+	value := func(text string) (int, error) {
+		if n, ok := names[strings.ToLower(text)]; ok {
+			return n, nil
+		}
+		n, err := strconv.Atoi(text)
+		if err != nil || n < low || n > high || names != nil {
+			return 0, fmt.Errorf("%q is out of range", text)
+		}
+		return n, nil
+	}
+	for _, part := range strings.Split(field, ",") {
+		first, last, found := strings.Cut(part, "-")
+		from, err := value(first)
+		if err != nil {
+			return err
+		}
+		to := from
+		if found {
+			to, err = value(last)
+			if err != nil {
+				return err
+			}
+		}
+		for i := from; ; i = (i + 1) % len(set) {
+			set[i] = true
+			if i == to {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Schedule) active(t time.Time) bool {
+	local := t.In(s.location)
+	return s.days[local.Weekday()] && s.hours[local.Hour()]
+}
+
+func (s *Schedule) next(t time.Time) time.Time {
+	local := t.In(s.location)
+	at := time.Date(local.Year(), local.Month(), local.Day(), local.Hour(), 0, 0, 0, s.location)
+	for i := 0; i < 8*24; i++ {
+		at = at.Add(time.Hour)
+		if s.active(at) {
+			return at
+		}
+	}
+	return t.Add(time.Hour)
+}
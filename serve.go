@@ -0,0 +1,285 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Trending struct {
+	window    time.Duration
+	sightings []sighting
+	counts    map[string]int
+	mutex     sync.Mutex
+}
+
+type sighting struct {
+	term string
+	link string
+	at   time.Time
+}
+
+type TrendingReport struct {
+	Window string         `json:"window"`
+	Terms  []TrendingTerm `json:"terms"`
+}
+
+type TrendingTerm struct {
+	Term  string   `json:"term"`
+	Count int      `json:"count"`
+	Links []string `json:"links"`
+}
+
+type Metrics struct {
+	behindProxy bool
+	endpoints   map[string]*endpoint
+	mutex       sync.Mutex
+}
+
+type endpoint struct {
+	requests int
+	errors   int
+	bytes    int64
+	latency  time.Duration
+}
+
+type EndpointMetrics struct {
+	Requests  int     `json:"requests"`
+	Errors    int     `json:"errors"`
+	Bytes     int64   `json:"bytes"`
+	LatencyMs float64 `json:"latency_ms"`
+}
+
+type MetricsReport struct {
+	Endpoints  map[string]EndpointMetrics `json:"endpoints"`
+	Downloaded DownloadMetrics            `json:"downloaded"`
+}
+
+type DownloadMetrics struct {
+	Today   int64            `json:"today"`
+	Overall int64            `json:"overall"`
+	Feeds   map[string]int64 `json:"feeds"`
+}
+
+type recorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+const trendingLinks = 3
+
+func newTrending(window time.Duration) *Trending {
+	return &Trending{window: window, counts: make(map[string]int)}
+}
+
+func (t *Trending) Name() string {
+	return "trending"
+}
+
+func (t *Trending) Key() string {
+	return "trending"
+}
+
+func (t *Trending) Deliver(entry *Entry) error {
+	now := clock.Now()
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.expire(now)
+	for _, term := range keywords(entry) {
+		t.sightings = append(t.sightings, sighting{term: term, link: entry.Item.Link, at: now})
+		t.counts[term]++
+	}
+	return nil
+}
+
+func (t *Trending) expire(now time.Time) {
+	n := 0
+	for n < len(t.sightings) && now.Sub(t.sightings[n].at) > t.window {
+		term := t.sightings[n].term
+		t.counts[term]--
+		if t.counts[term] == 0 {
+			delete(t.counts, term)
+		}
+		n++
+	}
+	t.sightings = t.sightings[n:]
+}
+
+func (t *Trending) top(limit int) []TrendingTerm {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.expire(clock.Now())
+	terms := make([]TrendingTerm, 0, len(t.counts))
+	for term, count := range t.counts {
+		terms = append(terms, TrendingTerm{Term: term, Count: count, Links: []string{}})
+	}
+	sort.Slice(terms, func(i, j int) bool {
+		if terms[i].Count != terms[j].Count {
+			return terms[i].Count > terms[j].Count
+		}
+		return terms[i].Term < terms[j].Term
+	})
+	if len(terms) > limit {
+		terms = terms[:limit]
+	}
+	index := make(map[string]int, len(terms))
+	for i, term := range terms {
+		index[term.Term] = i
+	}
+	for i := len(t.sightings) - 1; i >= 0; i-- {
+		s := t.sightings[i]
+		if j, ok := index[s.term]; ok && s.link != "" && len(terms[j].Links) < trendingLinks {
+			terms[j].Links = append(terms[j].Links, s.link)
+		}
+	}
+	return terms
+}
+
+func (t *Trending) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	limit := 20
+	if value := r.URL.Query().Get("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+		limit = n
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(TrendingReport{Window: t.window.String(), Terms: t.top(limit)})
+}
+
+func newServeMux(aggregate *Aggregate, trending *Trending, metrics *Metrics) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.Handle("GET /feed", aggregate)
+	mux.Handle("GET /feed.xml", aggregate)
+	mux.HandleFunc("GET /items", aggregate.Records)
+	mux.Handle("GET /trending", trending)
+	mux.Handle("GET /metrics", metrics)
+	mux.HandleFunc("GET /healthz", healthz)
+	return mux
+}
+
+func healthz(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+}
+
+func newMetrics(behindProxy bool) *Metrics {
+	return &Metrics{behindProxy: behindProxy, endpoints: make(map[string]*endpoint)}
+}
+
+func (m *Metrics) observe(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started := time.Now()
+		rec := &recorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		latency := time.Since(started)
+		name := r.Pattern
+		if name == "" {
+			name = "other"
+		}
+		m.mutex.Lock()
+		e, ok := m.endpoints[name]
+		if !ok {
+			e = &endpoint{}
+			m.endpoints[name] = e
+		}
+		e.requests++
+		if rec.status >= 500 {
+			e.errors++
+		}
+		e.bytes += rec.bytes
+		e.latency += latency
+		m.mutex.Unlock()
+		if logger != nil {
+			logger.Printf("access client=%s method=%s path=%q status=%d bytes=%d latency=%s agent=%q",
+				m.client(r), r.Method, r.URL.Path, rec.status, rec.bytes, latency.Round(time.Microsecond), r.UserAgent())
+		}
+	})
+}
+
+func (m *Metrics) client(r *http.Request) string {
+	if m.behindProxy {
+		if forwarded := r.Header.Values("X-Forwarded-For"); len(forwarded) > 0 {
+			hops := strings.Split(forwarded[len(forwarded)-1], ",")
+			if hop := strings.TrimSpace(hops[len(hops)-1]); hop != "" {
+				return hop
+			}
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mutex.Lock()
+	report := make(map[string]EndpointMetrics, len(m.endpoints))
+	for name, e := range m.endpoints {
+		report[name] = EndpointMetrics{
+			Requests:  e.requests,
+			Errors:    e.errors,
+			Bytes:     e.bytes,
+			LatencyMs: float64(e.latency.Microseconds()) / 1000 / float64(e.requests),
+		}
+	}
+	m.mutex.Unlock()
+	today, overall, feeds := bandwidth.snapshot()
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(MetricsReport{
+		Endpoints:  report,
+		Downloaded: DownloadMetrics{Today: today, Overall: overall, Feeds: feeds},
+	})
+}
+
+func (r *recorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *recorder) Write(data []byte) (int, error) {
+	n, err := r.ResponseWriter.Write(data)
+	r.bytes += int64(n)
+	return n, err
+}
+
+func protect(next http.Handler, token string, user string, password string) http.Handler {
+	if token == "" && user == "" {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/healthz" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if token != "" && equal(r.Header.Get("Authorization"), "Bearer "+token) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if name, secret, ok := r.BasicAuth(); ok && user != "" && equal(name, user) && equal(secret, password) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if user != "" {
+			w.Header().Set("WWW-Authenticate", `Basic realm="rssp"`)
+		}
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+	})
+}
+
+func equal(a string, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
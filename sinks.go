@@ -0,0 +1,264 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type Entry struct {
+	Feed        string
+	Channel     Channel
+	Item        *Item
+	Published   time.Time
+	Fetched     time.Time
+	Description string
+	Content     string
+	Summary     string
+	Entities    *Entities
+	Archived    string
+	Rejected    string
+}
+
+type Sink interface {
+	Name() string
+	Key() string
+	Deliver(entry *Entry) error
+}
+
+type Flusher interface {
+	Flush() error
+}
+
+type Queue struct {
+	jobs    chan func()
+	pending sync.WaitGroup
+}
+
+const queueSize = 1000
+
+var (
+	sinks      []Sink
+	queues     map[Sink]*Queue
+	sinkClient = &http.Client{Timeout: time.Minute}
+)
+
+func newEntry(feedURL string, channel *Channel, item *Item) *Entry {
+	meta := *channel
+	meta.Items = nil
+	entry := &Entry{Feed: feedURL, Channel: meta, Item: item, Description: strip(item.Description)}
+	entry.Published, _ = parseTime(item.PubDate)
+	entry.Fetched = clock.Now()
+	return entry
+}
+
+func deliver(entry *Entry) {
+	for _, sink := range sinks {
+		dispatch(sink, func() { deliverTo(sink, entry) })
+	}
+}
+
+func deliverTo(sink Sink, entry *Entry) {
+	err := sink.Deliver(entry)
+	if errors.Is(err, errInFlight) {
+		return
+	}
+	if err != nil {
+		if logger != nil {
+			logger.Printf("Failed to deliver '%s' to %s: %v", entry.Item.Title, sink.Name(), err)
+		}
+		return
+	}
+	if logger != nil {
+		logger.Printf("Delivered '%s' to %s", entry.Item.Title, sink.Name())
+	}
+}
+
+func flush() {
+	for _, sink := range sinks {
+		flusher, ok := sink.(Flusher)
+		if !ok {
+			continue
+		}
+		dispatch(sink, func() {
+			err := flusher.Flush()
+			if err != nil && logger != nil {
+				logger.Printf("Failed to flush %s: %v", sink.Name(), err)
+			}
+		})
+	}
+}
+
+func drain(timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		flush()
+		for _, queue := range queues {
+			queue.pending.Wait()
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+func startQueues() {
+	queues = make(map[Sink]*Queue, len(sinks))
+	for _, sink := range sinks {
+		queue := &Queue{jobs: make(chan func(), queueSize)}
+		queues[sink] = queue
+		go queue.run()
+	}
+}
+
+func dispatch(sink Sink, job func()) {
+	queue, ok := queues[sink]
+	if !ok {
+		job()
+		return
+	}
+	queue.pending.Add(1)
+	select {
+	case queue.jobs <- job:
+	default:
+		queue.pending.Done()
+		if logger != nil {
+			logger.Printf("The queue of %s is full, dropping a delivery", sink.Name())
+		}
+	}
+}
+
+func (q *Queue) run() {
+	for job := range q.jobs {
+		job()
+		q.pending.Done()
+	}
+}
+
+func (e *Entry) Tags() []string {
+	if e.Entities == nil {
+		return nil
+	}
+	var tags []string
+	seen := make(map[string]bool)
+	for _, names := range [][]string{e.Entities.Companies, e.Entities.Products, e.Entities.People} {
+		for _, name := range names {
+			if name != "" && !seen[name] {
+				seen[name] = true
+				tags = append(tags, name)
+			}
+		}
+	}
+	return tags
+}
+
+const recordSchema = "rssp/v1"
+
+type Record struct {
+	Schema      string            `json:"schema"`
+	Feed        string            `json:"feed"`
+	Channel     string            `json:"channel,omitempty"`
+	ChannelLink string            `json:"channel_link,omitempty"`
+	ChannelInfo string            `json:"channel_description,omitempty"`
+	ChannelIcon string            `json:"channel_image,omitempty"`
+	Language    string            `json:"language,omitempty"`
+	Title       string            `json:"title"`
+	Link        string            `json:"link,omitempty"`
+	Image       string            `json:"image,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Content     string            `json:"content,omitempty"`
+	Summary     string            `json:"summary,omitempty"`
+	Published   string            `json:"published,omitempty"`
+	PublishedAt string            `json:"published_at,omitempty"`
+	DateSeen    bool              `json:"date_seen,omitempty"`
+	GUID        string            `json:"guid,omitempty"`
+	Entities    *Entities         `json:"entities,omitempty"`
+	Archived    string            `json:"archived,omitempty"`
+	Rejected    string            `json:"rejected,omitempty"`
+	Extra       map[string]string `json:"extra,omitempty"`
+	Point       *Point            `json:"point,omitempty"`
+	Dateline    string            `json:"dateline,omitempty"`
+	Fetched     string            `json:"fetched,omitempty"`
+}
+
+func (e *Entry) Record() Record {
+	record := Record{
+		Schema:      recordSchema,
+		Feed:        e.Feed,
+		Channel:     e.Channel.Title,
+		ChannelLink: e.Channel.Link,
+		ChannelInfo: strip(e.Channel.Description),
+		ChannelIcon: e.Channel.artwork(),
+		Language:    language(e.Item, &e.Channel),
+		Title:       strip(e.Item.Title),
+		Link:        e.Item.Link,
+		Image:       e.Item.thumbnail(),
+		Description: e.Description,
+		Content:     e.Content,
+		Summary:     e.Summary,
+		Published:   e.Item.PubDate,
+		DateSeen:    e.Item.Seen,
+		GUID:        getItemID(e.Item),
+		Entities:    e.Entities,
+		Archived:    e.Archived,
+		Rejected:    e.Rejected,
+		Extra:       e.Item.Extra,
+		Point:       e.Item.point(),
+		Dateline:    e.place(),
+	}
+	if !e.Published.IsZero() {
+		record.PublishedAt = e.Published.UTC().Format(time.RFC3339)
+	}
+	if !e.Fetched.IsZero() {
+		record.Fetched = e.Fetched.UTC().Format(time.RFC3339)
+	}
+	return record
+}
+
+func (e *Entry) text() string {
+	if e.Summary != "" {
+		return e.Summary
+	}
+	if e.Description != "" {
+		return e.Description
+	}
+	return e.Content
+}
+
+func (e *Entry) guid() string {
+	id := getItemID(e.Item)
+	if id == "" {
+		id = summaryKey(e.Item.Title)[:16]
+	}
+	return summaryKey(e.Feed)[:8] + ":" + id
+}
+
+func (e *Entry) date() string {
+	if e.Published.IsZero() {
+		return e.Item.PubDate
+	}
+	return e.Published.Format("02-01-2006")
+}
+
+func (e *Entry) source() string {
+	if e.Channel.Title != "" {
+		return e.Channel.Title
+	}
+	return hostname(e.Feed)
+}
+
+func truncate(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	return string(runes[:limit-1]) + "…"
+}
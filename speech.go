@@ -0,0 +1,153 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+type Speech struct {
+	dir     string
+	player  string
+	engine  string
+	voice   string
+	token   string
+	mutex   sync.Mutex
+	clips   chan []byte
+	once    sync.Once
+	playing sync.WaitGroup
+}
+
+type SpeechRequest struct {
+	Model  string `json:"model"`
+	Input  string `json:"input"`
+	Voice  string `json:"voice"`
+	Format string `json:"response_format"`
+}
+
+const (
+	maxSpeechLength = 4096
+	speechQueue     = 32
+)
+
+func (s *Speech) Name() string {
+	return "speech"
+}
+
+func (s *Speech) Key() string {
+	return "speech"
+}
+
+func (s *Speech) Deliver(entry *Entry) error {
+	text := entry.text()
+	if title := strip(entry.Item.Title); title != "" {
+		text = join(strings.TrimRight(title, "."), ". ", text)
+	}
+	if text == "" {
+		return nil
+	}
+	audio, err := s.synthesize(truncate(text, maxSpeechLength))
+	if err != nil {
+		return err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.dir != "" {
+		if err := s.save(entry, audio); err != nil {
+			return err
+		}
+	}
+	if s.player != "" {
+		s.once.Do(func() {
+			s.clips = make(chan []byte, speechQueue)
+			go s.play()
+		})
+		s.playing.Add(1)
+		select {
+		case s.clips <- audio:
+		default:
+			s.playing.Done()
+			return fmt.Errorf("%d clips are waiting for the speech player already", speechQueue)
+		}
+	}
+	return nil
+}
+
+func (s *Speech) Flush() error {
+	s.playing.Wait()
+	return nil
+}
+
+func (s *Speech) play() {
+	for audio := range s.clips {
+		cmd := shell(s.player)
+		cmd.Stdin = bytes.NewReader(audio)
+		output, err := cmd.CombinedOutput()
+		if err != nil && logger != nil {
+			logger.Printf("Speech player failed: %v: %s", err, strings.TrimSpace(string(output)))
+		}
+		s.playing.Done()
+	}
+}
+
+func (s *Speech) synthesize(text string) ([]byte, error) {
+	if s.engine != "" {
+		cmd := shell(s.engine)
+		cmd.Stdin = strings.NewReader(text)
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		audio, err := cmd.Output()
+		if err != nil {
+			return nil, fmt.Errorf("speech engine failed: %v: %s", err, strings.TrimSpace(stderr.String()))
+		}
+		return audio, nil
+	}
+	if s.token == "" {
+		return nil, errors.New("OPENAI_API_KEY is not set")
+	}
+	body, err := json.Marshal(SpeechRequest{Model: "tts-1", Input: text, Voice: s.voice, Format: "mp3"})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(shutdown, "POST", openaiURL+"/audio/speech", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create speech request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+s.token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := sinkClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send speech request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OpenAI speech API error %d", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func (s *Speech) save(entry *Entry, audio []byte) error {
+	published := entry.Published
+	if published.IsZero() {
+		published = clock.Now()
+	}
+	name := published.Format("2006-01-02") + " " + slug(strip(entry.Item.Title))
+	path := filepath.Join(s.dir, name+".mp3")
+	for i := 2; ; i++ {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			break
+		}
+		path = filepath.Join(s.dir, fmt.Sprintf("%s %d.mp3", name, i))
+	}
+	return os.WriteFile(path, audio, 0644)
+}
@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+var commonWords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "from": true, "new": true, "how": true,
+	"why": true, "what": true, "who": true, "when": true, "this": true, "that": true, "are": true,
+	"was": true, "will": true, "its": true, "your": true, "you": true, "after": true, "over": true,
+	"into": true, "about": true, "more": true, "than": true, "says": true, "has": true, "have": true,
+	"not": true, "now": true, "out": true, "top": true, "best": true, "first": true, "update": true,
+	"news": true, "report": true, "week": true, "day": true, "year": true, "today": true,
+}
+
+type Spikes struct {
+	window    time.Duration
+	threshold int
+	mentions  map[string]map[string]time.Time
+	alerted   map[string]time.Time
+	mutex     sync.Mutex
+}
+
+type mention struct {
+	term  string
+	feeds int
+}
+
+func newSpikes(window time.Duration, threshold int) *Spikes {
+	return &Spikes{
+		window:    window,
+		threshold: threshold,
+		mentions:  make(map[string]map[string]time.Time),
+		alerted:   make(map[string]time.Time),
+	}
+}
+
+func (s *Spikes) Name() string {
+	return "keyword spikes"
+}
+
+func (s *Spikes) Key() string {
+	return "spikes"
+}
+
+func (s *Spikes) Deliver(entry *Entry) error {
+	now := clock.Now()
+	s.mutex.Lock()
+	for term, feeds := range s.mentions {
+		for feed, seen := range feeds {
+			if now.Sub(seen) > s.window {
+				delete(feeds, feed)
+			}
+		}
+		if len(feeds) == 0 {
+			delete(s.mentions, term)
+		}
+	}
+	for term, alerted := range s.alerted {
+		if now.Sub(alerted) > s.window {
+			delete(s.alerted, term)
+		}
+	}
+	var spikes []mention
+	for _, term := range keywords(entry) {
+		feeds, ok := s.mentions[term]
+		if !ok {
+			feeds = make(map[string]time.Time)
+			s.mentions[term] = feeds
+		}
+		feeds[entry.Feed] = now
+		if len(feeds) >= s.threshold && now.Sub(s.alerted[term]) > s.window {
+			s.alerted[term] = now
+			spikes = append(spikes, mention{term, len(feeds)})
+		}
+	}
+	s.mutex.Unlock()
+	for _, spike := range spikes {
+		if logger != nil {
+			logger.Printf("Keyword spike: '%s' mentioned by %d feeds within %s", spike.term, spike.feeds, s.window)
+		}
+		if outputFormat == "text" {
+			outputMutex.Lock()
+			writeOutput(fmt.Sprintf("ALERT: '%s' mentioned by %d feeds within %s\n\n", spike.term, spike.feeds, s.window))
+			outputMutex.Unlock()
+		}
+	}
+	return nil
+}
+
+func keywords(entry *Entry) []string {
+	seen := make(map[string]bool)
+	for _, tag := range entry.Tags() {
+		seen[tag] = true
+	}
+	for _, word := range strings.Fields(strip(entry.Item.Title)) {
+		word = strings.TrimFunc(word, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+		runes := []rune(word)
+		if len(runes) < 3 || !unicode.IsUpper(runes[0]) || commonWords[strings.ToLower(word)] {
+			continue
+		}
+		seen[word] = true
+	}
+	terms := make([]string, 0, len(seen))
+	for term := range seen {
+		terms = append(terms, term)
+	}
+	sort.Strings(terms)
+	return terms
+}
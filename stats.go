@@ -0,0 +1,331 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+type FeedStats struct {
+	Since     time.Time            `json:"since"`
+	Fetches   int                  `json:"fetches"`
+	Errors    int                  `json:"errors"`
+	Latency   time.Duration        `json:"latency"`
+	Items     int                  `json:"items"`
+	LastItem  time.Time            `json:"last_item,omitzero"`
+	LastError string               `json:"last_error,omitempty"`
+	Alive     time.Time            `json:"alive,omitzero"`
+	Dead      bool                 `json:"dead,omitempty"`
+	MovedTo   string               `json:"moved_to,omitempty"`
+	Hour      time.Time            `json:"hour,omitzero"`
+	HourItems int                  `json:"hour_items,omitempty"`
+	Hourly    float64              `json:"hourly,omitempty"`
+	Hours     int                  `json:"hours,omitempty"`
+	Quiet     int                  `json:"quiet,omitempty"`
+	Seen      map[string]time.Time `json:"seen,omitempty"`
+	Bytes     int64                `json:"bytes,omitempty"`
+}
+
+type Stats struct {
+	path  string
+	mutex sync.Mutex
+	Feeds map[string]*FeedStats `json:"feeds"`
+}
+
+const (
+	anomalyWarmup  = 24
+	anomalyFactor  = 5
+	anomalyMinimum = 10
+	anomalyWeight  = 0.1
+)
+
+var (
+	stats       *Stats
+	deadAfter   = 30 * 24 * time.Hour
+	disableDead bool
+)
+
+func loadStats(path string) (*Stats, error) {
+	s := &Stats{path: path, Feeds: make(map[string]*FeedStats)}
+	if path == "" {
+		return s, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if s.Feeds == nil {
+		s.Feeds = make(map[string]*FeedStats)
+	}
+	return s, nil
+}
+
+func (s *Stats) feed(url string) *FeedStats {
+	f, ok := s.Feeds[url]
+	if !ok {
+		f = &FeedStats{Since: clock.Now()}
+		s.Feeds[url] = f
+	}
+	return f
+}
+
+func (s *Stats) fetched(url string, latency time.Duration, feed *RSS, err error) {
+	if s == nil {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	f := s.feed(url)
+	f.Fetches++
+	f.Latency += latency
+	if err != nil {
+		f.Errors++
+		f.LastError = err.Error()
+		return
+	}
+	f.LastError = ""
+	if len(feed.Channel.Items) > 0 {
+		f.Alive = clock.Now()
+		f.Dead = false
+	}
+	for _, item := range feed.Channel.Items {
+		if published, ok := parseTime(item.PubDate); ok && published.After(f.LastItem) {
+			f.LastItem = published
+		}
+	}
+}
+
+func (s *Stats) dead(url string, period time.Duration) (bool, bool) {
+	if s == nil || period <= 0 {
+		return false, false
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	f := s.feed(url)
+	since := f.Since
+	if f.Alive.After(since) {
+		since = f.Alive
+	}
+	if clock.Now().Sub(since) < period {
+		return false, false
+	}
+	became := !f.Dead
+	f.Dead = true
+	return true, became
+}
+
+func (s *Stats) moved(url string, location string) {
+	if s == nil {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.feed(url).MovedTo = location
+}
+
+func (s *Stats) location(url string) string {
+	if s == nil {
+		return ""
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if f, ok := s.Feeds[url]; ok {
+		return f.MovedTo
+	}
+	return ""
+}
+
+func (s *Stats) remember(url string, items map[string]time.Time) {
+	if s == nil || s.path == "" {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	seen := make(map[string]time.Time, len(items))
+	for id, at := range items {
+		seen[id] = at
+	}
+	s.feed(url).Seen = seen
+}
+
+func (s *Stats) seen(url string) map[string]time.Time {
+	if s == nil {
+		return nil
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	f, ok := s.Feeds[url]
+	if !ok || len(f.Seen) == 0 {
+		return nil
+	}
+	seen := make(map[string]time.Time, len(f.Seen))
+	for id, at := range f.Seen {
+		seen[id] = at
+	}
+	return seen
+}
+
+func (s *Stats) added(url string, count int) {
+	if s == nil {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.feed(url).Items += count
+}
+
+func (s *Stats) downloaded(url string, n int64) {
+	if s == nil || n <= 0 {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.feed(url).Bytes += n
+}
+
+func (s *Stats) anomaly(url string, count int) string {
+	if s == nil {
+		return ""
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	f := s.feed(url)
+	now := clock.Now()
+	if f.Hour.IsZero() {
+		f.Hour = now
+	}
+	report := ""
+	if now.Sub(f.Hour) >= time.Hour {
+		usual := fmt.Sprintf("usually %.1f per hour", f.Hourly)
+		if f.HourItems == 0 {
+			f.Quiet++
+		} else {
+			f.Quiet = 0
+		}
+		if f.Hours >= anomalyWarmup {
+			if f.HourItems >= anomalyMinimum && float64(f.HourItems) > anomalyFactor*f.Hourly {
+				report = fmt.Sprintf("%d items in the last hour, %s", f.HourItems, usual)
+			}
+			expected := float64(f.Quiet) * f.Hourly
+			if f.Quiet > 0 && expected >= anomalyMinimum && expected-f.Hourly < anomalyMinimum {
+				report = fmt.Sprintf("no items for %d hours, %s", f.Quiet, usual)
+			}
+		}
+		if f.Hours == 0 {
+			f.Hourly = float64(f.HourItems)
+		} else {
+			f.Hourly = f.Hourly*(1-anomalyWeight) + float64(f.HourItems)*anomalyWeight
+		}
+		f.Hours++
+		f.Hour = now
+		f.HourItems = 0
+	}
+	f.HourItems += count
+	return report
+}
+
+func (s *Stats) save() error {
+	if s == nil || s.path == "" {
+		return nil
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	temp, err := os.CreateTemp(filepath.Dir(s.path), ".rssp-state-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name())
+	if _, err := temp.Write(data); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(temp.Name(), s.path)
+}
+
+func report(args []string, out io.Writer) int {
+	flags := flag.NewFlagSet("report", flag.ContinueOnError)
+	path := flags.String("state-file", "rssp-state.json", "State file written by rssp --state-file")
+	if err := flags.Parse(args); err != nil {
+		return exitConfig
+	}
+	s, err := loadStats(*path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+	if len(s.Feeds) == 0 {
+		fmt.Fprintf(os.Stderr, "No statistics found in %s\n", *path)
+		return exitFailure
+	}
+	urls := make([]string, 0, len(s.Feeds))
+	for url := range s.Feeds {
+		urls = append(urls, url)
+	}
+	sort.Strings(urls)
+	now := clock.Now()
+	table := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "FEED\tITEMS/DAY\tERRORS\tLATENCY\tDOWNLOADED\tLAST ITEM\tSTATUS")
+	for _, url := range urls {
+		f := s.Feeds[url]
+		days := now.Sub(f.Since).Hours() / 24
+		if days < 1 {
+			days = 1
+		}
+		rate, latency := 0.0, time.Duration(0)
+		if f.Fetches > 0 {
+			rate = float64(f.Errors) * 100 / float64(f.Fetches)
+			latency = f.Latency / time.Duration(f.Fetches)
+		}
+		age := "never"
+		if !f.LastItem.IsZero() {
+			age = ago(now.Sub(f.LastItem))
+		}
+		status := "ok"
+		if f.Dead {
+			status = "dead"
+		}
+		if f.MovedTo != "" {
+			status = "moved to " + f.MovedTo
+		}
+		fmt.Fprintf(table, "%s\t%.1f\t%.0f%%\t%s\t%s\t%s\t%s\n", url, float64(f.Items)/days, rate, latency.Round(time.Millisecond), formatSize(f.Bytes), age, status)
+	}
+	table.Flush()
+	return exitOK
+}
+
+func ago(d time.Duration) string {
+	switch {
+	case d < 0:
+		return "in the future"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+}
@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+)
+
+type View struct {
+	Date        string
+	Published   time.Time
+	Title       string
+	Link        string
+	Host        string
+	Feed        string
+	Channel     string
+	Description string
+	Content     string
+	Summary     string
+	Text        string
+	Image       string
+	Language    string
+	Tags        []string
+}
+
+var itemTemplate *template.Template
+
+func newItemTemplate(text string, file string) (*template.Template, error) {
+	if file != "" {
+		if text != "" {
+			return nil, fmt.Errorf("--template and --template-file can't be used together")
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read output template: %w", err)
+		}
+		text = string(data)
+	}
+	tmpl, err := template.New("item").Funcs(template.FuncMap{"label": translate}).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse output template: %w", err)
+	}
+	return tmpl, nil
+}
+
+func renderItem(tmpl *template.Template, entry *Entry) (string, error) {
+	var text strings.Builder
+	err := tmpl.Execute(&text, View{
+		Date:        entry.date(),
+		Published:   entry.Published,
+		Title:       strip(entry.Item.Title),
+		Link:        entry.Item.Link,
+		Host:        hostname(entry.Item.Link),
+		Feed:        entry.Feed,
+		Channel:     entry.Channel.Title,
+		Description: entry.Description,
+		Content:     entry.Content,
+		Summary:     entry.Summary,
+		Text:        entry.text(),
+		Image:       entry.Item.thumbnail(),
+		Language:    language(entry.Item, &entry.Channel),
+		Tags:        entry.Tags(),
+	})
+	if err != nil {
+		return "", err
+	}
+	if !strings.HasSuffix(text.String(), "\n") {
+		text.WriteString("\n")
+	}
+	return text.String(), nil
+}
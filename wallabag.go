@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+type Wallabag struct {
+	url          string
+	clientID     string
+	clientSecret string
+	username     string
+	password     string
+	token        string
+	mutex        sync.Mutex
+}
+
+type WallabagToken struct {
+	AccessToken string `json:"access_token"`
+}
+
+func (w *Wallabag) Name() string {
+	return "Wallabag"
+}
+
+func (w *Wallabag) Key() string {
+	return "wallabag"
+}
+
+func (w *Wallabag) Deliver(entry *Entry) error {
+	link := entry.Item.Link
+	if link == "" {
+		return nil
+	}
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.token == "" {
+		err := w.authenticate()
+		if err != nil {
+			return err
+		}
+	}
+	status, err := w.save(link, entry.Tags())
+	if err == nil && status == http.StatusUnauthorized {
+		err = w.authenticate()
+		if err == nil {
+			status, err = w.save(link, entry.Tags())
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK {
+		return fmt.Errorf("Wallabag API error %d", status)
+	}
+	return nil
+}
+
+func (w *Wallabag) authenticate() error {
+	form := url.Values{
+		"grant_type":    {"password"},
+		"client_id":     {w.clientID},
+		"client_secret": {w.clientSecret},
+		"username":      {w.username},
+		"password":      {w.password},
+	}
+	resp, err := sinkClient.PostForm(w.url+"/oauth/v2/token", form)
+	if err != nil {
+		return fmt.Errorf("failed to authenticate with Wallabag: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Wallabag authentication error %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read Wallabag token: %w", err)
+	}
+	var token WallabagToken
+	err = json.Unmarshal(body, &token)
+	if err != nil || token.AccessToken == "" {
+		return fmt.Errorf("failed to parse Wallabag token: %v", err)
+	}
+	w.token = token.AccessToken
+	return nil
+}
+
+func (w *Wallabag) save(link string, tags []string) (int, error) {
+	form := url.Values{"url": {link}}
+	if len(tags) > 0 {
+		form.Set("tags", strings.Join(tags, ","))
+	}
+	req, err := http.NewRequest("POST", w.url+"/api/entries.json", strings.NewReader(form.Encode()))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create Wallabag request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+w.token)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := sinkClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send Wallabag request: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,113 @@
+// SPDX-FileCopyrightText: Copyright (c) 2025 Yegor Bugayenko
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Webhook struct {
+	url      string
+	secret   string
+	batch    int
+	interval time.Duration
+	records  []Record
+	keys     []string
+	sent     time.Time
+	mutex    sync.Mutex
+}
+
+func (w *Webhook) Name() string {
+	return "webhook"
+}
+
+func (w *Webhook) Key() string {
+	return "webhook"
+}
+
+func (w *Webhook) Deliver(entry *Entry) error {
+	if w.batch <= 1 {
+		body, err := json.Marshal(entry.Record())
+		if err != nil {
+			return err
+		}
+		return w.post(body, entry.idempotencyKey())
+	}
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.records = append(w.records, entry.Record())
+	w.keys = append(w.keys, entry.idempotencyKey())
+	if len(w.records) >= w.batch {
+		return w.send()
+	}
+	return nil
+}
+
+func (w *Webhook) Flush() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if len(w.records) == 0 || shutdown.Err() == nil && clock.Now().Sub(w.sent) < w.interval {
+		return nil
+	}
+	return w.send()
+}
+
+func (w *Webhook) send() error {
+	for len(w.records) > 0 {
+		n := min(len(w.records), w.batch)
+		body, err := json.Marshal(w.records[:n])
+		if err != nil {
+			return err
+		}
+		if err := w.post(body, summaryKey(strings.Join(w.keys[:n], "\n"))); err != nil {
+			return err
+		}
+		w.records = w.records[n:]
+		w.keys = w.keys[n:]
+		w.sent = clock.Now()
+	}
+	return nil
+}
+
+func (w *Webhook) post(body []byte, key string) error {
+	req, err := http.NewRequest("POST", w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", key)
+	if w.secret != "" {
+		req.Header.Set("X-RSSP-Signature", signature(w.secret, body))
+	}
+	resp, err := sinkClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post to webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("webhook error %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (e *Entry) idempotencyKey() string {
+	return summaryKey(e.Feed + "\n" + e.guid())
+}
+
+func signature(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}